@@ -0,0 +1,8 @@
+action "remscontent_close_stale_drafts" "quarterly" {
+  config {
+    older_than_days = 90
+    comment         = "Closed after 90 days without activity - please apply again if still needed"
+  }
+}
+
+# run with: terraform apply -invoke=action.remscontent_close_stale_drafts.quarterly
@@ -0,0 +1,6 @@
+action "remscontent_revoke_application" "breach" {
+  config {
+    application_id = 1234
+    comment        = "Access withdrawn following the data breach review"
+  }
+}
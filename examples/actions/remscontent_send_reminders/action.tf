@@ -0,0 +1,20 @@
+action "remscontent_send_reminders" "handlers" {
+  config {
+    recipients = "handlers"
+  }
+}
+
+# remind handlers whenever the workflow changes hands
+resource "remscontent_workflow" "dataset" {
+  organization_id = "umccr"
+  title           = "Dataset access"
+  type            = "workflow/default"
+  handlers        = ["handler@example.org"]
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.remscontent_send_reminders.handlers]
+    }
+  }
+}
@@ -0,0 +1,16 @@
+variable "retiring_workflow_id" {
+  description = "Id of the workflow about to be archived"
+  type        = number
+}
+
+data "remscontent_applications" "all" {}
+
+check "retiring_workflow_has_no_open_applications" {
+  assert {
+    condition = length([
+      for a in data.remscontent_applications.all.applications : a
+      if a.open && a.workflow_id == var.retiring_workflow_id
+    ]) == 0
+    error_message = "The workflow about to be archived still has open applications."
+  }
+}
@@ -0,0 +1,18 @@
+variable "ethics_form_id" {
+  description = "Id of the form whose applications go to the ethics committee"
+  type        = number
+}
+
+data "remscontent_applications_export" "ethics" {
+  form_id = var.ethics_form_id
+}
+
+# an extract kept alongside each revision of the form
+resource "local_file" "ethics_applications" {
+  filename = "${path.module}/applications-form-${var.ethics_form_id}.csv"
+  content  = data.remscontent_applications_export.ethics.csv
+}
+
+output "ethics_application_count" {
+  value = length(data.remscontent_applications_export.ethics.rows)
+}
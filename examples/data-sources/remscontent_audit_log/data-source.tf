@@ -0,0 +1,15 @@
+data "remscontent_audit_log" "last_week" {
+  after = timeadd(plantimestamp(), "-168h")
+}
+
+# only the Terraform API user may change forms and workflows
+check "forms_and_workflows_changed_by_terraform" {
+  assert {
+    condition = alltrue([
+      for e in data.remscontent_audit_log.last_week.entries :
+      e.user_id == "terraform-bot"
+      if lower(e.method) != "get" && can(regex("^/api/(forms|workflows)/", e.path))
+    ])
+    error_message = "Forms or workflows were changed by someone other than Terraform."
+  }
+}
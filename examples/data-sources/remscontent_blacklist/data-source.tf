@@ -0,0 +1,3 @@
+data "remscontent_blacklist" "dataset" {
+  resource_resid = "urn:example:dataset:1"
+}
@@ -0,0 +1,12 @@
+data "remscontent_catalogue" "current" {}
+
+check "catalogue_items_usable" {
+  assert {
+    condition = alltrue([
+      for item in data.remscontent_catalogue.current.items :
+      item.workflow_enabled && coalesce(item.form_enabled, true)
+      if item.enabled
+    ])
+    error_message = "An enabled catalogue item uses a disabled or archived form or workflow."
+  }
+}
@@ -0,0 +1,16 @@
+variable "catalogue_item_ids" {
+  description = "Ids of the catalogue items managed by this configuration"
+  type        = list(number)
+}
+
+data "remscontent_catalogue_tree" "current" {}
+
+check "catalogue_items_categorised" {
+  assert {
+    condition = alltrue([
+      for id in var.catalogue_item_ids :
+      contains(data.remscontent_catalogue_tree.current.item_ids, id)
+    ])
+    error_message = "Every catalogue item should be in some category."
+  }
+}
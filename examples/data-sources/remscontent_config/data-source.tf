@@ -0,0 +1,14 @@
+data "remscontent_config" "rems" {}
+
+# a localization for every language of the instance, whichever instance it is
+resource "remscontent_catalogue_item" "dataset" {
+  organization_id = "umccr"
+  resource_id     = 1
+  workflow_id     = 1
+
+  localizations = {
+    for lang in data.remscontent_config.rems.languages : lang => {
+      title = "Dataset"
+    }
+  }
+}
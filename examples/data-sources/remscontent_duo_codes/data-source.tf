@@ -0,0 +1,3 @@
+data "remscontent_duo_codes" "disease_specific" {
+  label = "disease specific"
+}
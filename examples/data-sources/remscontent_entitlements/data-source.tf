@@ -0,0 +1,15 @@
+data "remscontent_entitlements" "dataset" {
+  resource = "urn:example:dataset:1"
+}
+
+# give every entitled researcher read access to the dataset bucket
+resource "google_storage_bucket_iam_member" "dataset" {
+  for_each = {
+    for e in data.remscontent_entitlements.dataset.entitlements : e.user_id => e
+    if e.user_email != null
+  }
+
+  bucket = "example-dataset-bucket"
+  role   = "roles/storage.objectViewer"
+  member = "user:${each.value.user_email}"
+}
@@ -0,0 +1,13 @@
+data "remscontent_entitlements_csv" "review" {
+  separator = ";"
+}
+
+# the artifact of the quarterly access review
+resource "local_file" "entitlements" {
+  filename = "${path.module}/entitlements.csv"
+  content  = data.remscontent_entitlements_csv.review.csv
+}
+
+output "entitlement_count" {
+  value = length(data.remscontent_entitlements_csv.review.rows)
+}
@@ -0,0 +1,8 @@
+data "remscontent_jwks" "rems" {}
+
+# the data serving service verifies visas against the REMS keys
+resource "aws_ssm_parameter" "rems_jwks" {
+  name  = "/data-service/rems-jwks"
+  type  = "String"
+  value = data.remscontent_jwks.rems.json
+}
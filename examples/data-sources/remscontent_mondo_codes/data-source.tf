@@ -0,0 +1,16 @@
+data "remscontent_mondo_codes" "melanoma" {
+  search_text = "melanoma"
+}
+
+resource "remscontent_resource" "melanoma_cohort" {
+  organization_id = "umccr"
+  resid           = "urn:example:dataset:melanoma"
+
+  duo_code {
+    id = "DUO:0000007" # disease specific research
+
+    restriction {
+      mondo = [for c in data.remscontent_mondo_codes.melanoma.codes : c.id if c.label == "melanoma"]
+    }
+  }
+}
@@ -0,0 +1,5 @@
+data "remscontent_workflow_actors" "all" {}
+
+output "handler_ids" {
+  value = data.remscontent_workflow_actors.all.userids
+}
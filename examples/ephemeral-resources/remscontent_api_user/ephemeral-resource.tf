@@ -0,0 +1,20 @@
+variable "smoke_test_api_key" {
+  description = "REMS API key restricted to the paths the smoke tests call"
+  type        = string
+  sensitive   = true
+}
+
+ephemeral "remscontent_api_user" "smoke_test" {
+  userid  = "terraform-smoke-test"
+  name    = "Smoke test"
+  api_key = var.smoke_test_api_key
+}
+
+# hand the identity to the smoke tests without it reaching the state
+provider "restapi" {
+  uri = "https://rems.example.org"
+  headers = {
+    x-rems-user-id = ephemeral.remscontent_api_user.smoke_test.userid
+    x-rems-api-key = ephemeral.remscontent_api_user.smoke_test.api_key
+  }
+}
@@ -1,3 +0,0 @@
-ephemeral "remscontent_example" "example" {
-  configurable_attribute = "some-value"
-}
@@ -0,0 +1,11 @@
+ephemeral "remscontent_ga4gh_visas" "researcher" {
+  user = "researcher@example.org"
+}
+
+# a real visa for the verification tests of the data serving service
+resource "aws_ssm_parameter" "test_visa" {
+  name             = "/data-service/test/visa"
+  type             = "SecureString"
+  value_wo         = ephemeral.remscontent_ga4gh_visas.researcher.visas[0].jwt
+  value_wo_version = 1
+}
@@ -0,0 +1,26 @@
+variable "dataset_catalogue_item_id" {
+  description = "Id of the catalogue item of the dataset"
+  type        = number
+}
+
+# Terraform opens ephemeral resources on every plan as well as every apply, so
+# each plan submits and approves an application. Only point this at a catalogue
+# item set aside for testing.
+ephemeral "remscontent_test_application" "dataset" {
+  catalogue_item_ids = [var.dataset_catalogue_item_id]
+  applicant          = "smoke-test-applicant"
+  submit             = true
+  approve_as         = "smoke-test-handler"
+
+  field_values = {
+    project-title = "Smoke test"
+    purpose       = "Checking the application flow after a change"
+  }
+}
+
+check "dataset_application_approved" {
+  assert {
+    condition     = ephemeral.remscontent_test_application.dataset.state == "application.state/approved"
+    error_message = "Applying for the dataset no longer ends in approval."
+  }
+}
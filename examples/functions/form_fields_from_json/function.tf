@@ -0,0 +1,7 @@
+resource "remscontent_form" "mgrb" {
+  organization_id = "Garvan Institute of Medical Research"
+  title           = "MGRB Form v2.0"
+
+  # a form exported from REMS with GET /api/forms/{form-id}
+  fields = provider::remscontent::form_fields_from_json(file("${path.module}/mgrb-form.json"))
+}
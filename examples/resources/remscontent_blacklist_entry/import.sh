@@ -0,0 +1 @@
+terraform import remscontent_blacklist_entry.example "researcher@example.org,urn:example:dataset:1"
@@ -0,0 +1,6 @@
+resource "remscontent_blacklist_entry" "example" {
+  user_id        = "researcher@example.org"
+  resource_resid = "urn:example:dataset:1"
+  comment        = "Breach of the data transfer agreement, DAC decision 2024-07"
+  remove_comment = "Reinstated, DAC decision 2025-02"
+}
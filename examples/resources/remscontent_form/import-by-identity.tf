@@ -0,0 +1,8 @@
+import {
+  to = remscontent_form.example
+  identity = {
+    id              = 12
+    type            = "form"
+    organization_id = "umccr"
+  }
+}
@@ -1 +1 @@
-terraform import remscontent_example.test "id-123"
\ No newline at end of file
+terraform import remscontent_form.example 12
@@ -0,0 +1,5 @@
+resource "remscontent_invitation" "committee_member" {
+  name        = "Dr Jane Smith"
+  email       = "jane.smith@example.org"
+  workflow_id = remscontent_workflow.default.id
+}
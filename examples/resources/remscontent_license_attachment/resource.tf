@@ -0,0 +1,20 @@
+resource "remscontent_license_attachment" "dta" {
+  source = "${path.module}/licenses/data-transfer-agreement-2025.pdf"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "remscontent_license" "dta" {
+  organization_id = "umccr"
+  type            = "attachment"
+
+  localizations = {
+    en = {
+      title         = "Data transfer agreement"
+      textcontent   = "data-transfer-agreement-2025.pdf"
+      attachment_id = remscontent_license_attachment.dta.id
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import remscontent_resource.example 12
@@ -0,0 +1,8 @@
+resource "remscontent_resource" "example" {
+  organization_id = "umccr"
+  resid           = "urn:example:dataset:1"
+
+  # destroying the resource withdraws the dataset from everyone approved for it
+  revoke_on_destroy = true
+  revoke_comment    = "The dataset has been withdrawn by its custodians"
+}
@@ -0,0 +1 @@
+terraform import remscontent_user.handler alice@example.org
@@ -0,0 +1,13 @@
+resource "remscontent_user" "handler" {
+  userid        = "alice@example.org"
+  name          = "Alice Handler"
+  email         = "alice@example.org"
+  organizations = ["umccr"]
+}
+
+# referring to the user makes sure it exists before the workflow is created
+resource "remscontent_workflow" "default" {
+  organization_id = "umccr"
+  title           = "Default workflow"
+  handlers        = [remscontent_user.handler.id]
+}
@@ -0,0 +1,683 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package generate writes Terraform configuration for the content of an
+// existing REMS instance, so that it can be brought under management by
+// importing it rather than transcribing it by hand.
+package generate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/resources"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Main runs the generate command with the arguments that follow "generate".
+func Main(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+
+	endpoint := flags.String("endpoint", os.Getenv("REMSCONTENT_ENDPOINT"), "REMS instance endpoint (DNS name only, not URI), defaults to $REMSCONTENT_ENDPOINT")
+	apiUser := flags.String("api-user", os.Getenv("REMSCONTENT_API_USER"), "REMS API user, defaults to $REMSCONTENT_API_USER")
+	apiKey := flags.String("api-key", os.Getenv("REMSCONTENT_API_KEY"), "REMS API key, defaults to $REMSCONTENT_API_KEY")
+	dir := flags.String("out", "generated", "directory to write the .tf files to")
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if *endpoint == "" || *apiUser == "" || *apiKey == "" {
+		return fmt.Errorf("an endpoint, api user and api key are all required")
+	}
+
+	files, err := Generate(ctx, provider.NewClient(*endpoint, *apiUser, *apiKey))
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		path := filepath.Join(*dir, file.Name)
+
+		if err := os.WriteFile(path, []byte(file.Content), 0o644); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "wrote %s\n", path)
+	}
+
+	return nil
+}
+
+// File is a generated .tf file.
+type File struct {
+	Name    string
+	Content string
+}
+
+// generator remembers the Terraform name given to each REMS object so that later
+// objects can refer to it rather than to its literal id.
+type generator struct {
+	client *remsclient.APIClient
+
+	organizations map[string]string
+	categories    map[int64]string
+	licenses      map[int64]string
+	forms         map[int64]string
+	resources     map[int64]string
+	workflows     map[int64]string
+}
+
+// Generate walks the content of a REMS instance and returns the configuration
+// for it, one file per type of object. Archived objects are left out.
+func Generate(ctx context.Context, client *remsclient.APIClient) ([]File, error) {
+	sections, err := generateSections(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+
+	for _, section := range sections {
+		var b strings.Builder
+
+		b.WriteString("# Generated by terraform-provider-remscontent generate\n")
+
+		for _, bl := range section.blocks {
+			b.WriteString("\n")
+			bl.write(&b)
+		}
+
+		files = append(files, File{Name: section.name, Content: b.String()})
+	}
+
+	return files, nil
+}
+
+// section is the blocks of one generated file.
+type section struct {
+	name   string
+	blocks []block
+}
+
+// generateSections returns the blocks of each file that has any.
+func generateSections(ctx context.Context, client *remsclient.APIClient) ([]section, error) {
+	g := &generator{
+		client:        client,
+		organizations: map[string]string{},
+		categories:    map[int64]string{},
+		licenses:      map[int64]string{},
+		forms:         map[int64]string{},
+		resources:     map[int64]string{},
+		workflows:     map[int64]string{},
+	}
+
+	// each step can only refer to the objects of the steps before it (or, for
+	// categories, to each other)
+	steps := []struct {
+		name string
+		run  func(ctx context.Context) ([]block, error)
+	}{
+		{"organizations.tf", g.organizationBlocks},
+		{"categories.tf", g.categoryBlocks},
+		{"licenses.tf", g.licenseBlocks},
+		{"forms.tf", g.formBlocks},
+		{"resources.tf", g.resourceBlocks},
+		{"workflows.tf", g.workflowBlocks},
+		{"catalogue_items.tf", g.catalogueItemBlocks},
+	}
+
+	var sections []section
+
+	for _, step := range steps {
+		blocks, err := step.run(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(blocks) == 0 {
+			continue
+		}
+
+		sections = append(sections, section{name: step.name, blocks: blocks})
+	}
+
+	return sections, nil
+}
+
+func (g *generator) organizationBlocks(ctx context.Context) ([]block, error) {
+	organizations, httpResp, err := g.client.OrganizationsAPI.ApiOrganizationsGet(ctx).Disabled(true).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list organizations: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].OrganizationId < organizations[j].OrganizationId
+	})
+
+	// organizations are managed outside of terraform so are only gathered into
+	// a local for the other objects to refer to
+	n := names{}
+	ids := object{}
+
+	for _, o := range organizations {
+		if o.Archived != nil && *o.Archived {
+			continue
+		}
+
+		name := n.name(o.OrganizationId, "organization")
+		g.organizations[o.OrganizationId] = name
+		ids = append(ids, attribute{Name: name, Value: stringValue(o.OrganizationId)})
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return []block{{
+		Type:       "locals",
+		Attributes: []attribute{{Name: "organizations", Value: ids}},
+	}}, nil
+}
+
+func (g *generator) categoryBlocks(ctx context.Context) ([]block, error) {
+	categories, httpResp, err := g.client.CategoriesAPI.ApiCategoriesGet(ctx).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list categories: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(categories, func(i, j int) bool { return categories[i].CategoryId < categories[j].CategoryId })
+
+	// categories refer to each other, so every name is needed up front
+	n := names{}
+	for _, c := range categories {
+		g.categories[c.CategoryId] = n.name(resources.LocalizedText(c.CategoryTitle), "category")
+	}
+
+	var blocks []block
+
+	for _, c := range categories {
+		attributes := []attribute{
+			{Name: "title", Value: stringMap(c.CategoryTitle)},
+		}
+
+		if c.CategoryDescription != nil {
+			attributes = append(attributes, attribute{Name: "description", Value: stringMap(*c.CategoryDescription)})
+		}
+
+		if c.CategoryDisplayOrder != nil {
+			attributes = append(attributes, attribute{Name: "display_order", Value: intValue(*c.CategoryDisplayOrder)})
+		}
+
+		if len(c.CategoryChildren) > 0 {
+			children := list{}
+			for _, child := range c.CategoryChildren {
+				children = append(children, g.ref("remscontent_category", g.categories, child.CategoryId))
+			}
+			attributes = append(attributes, attribute{Name: "children", Value: children})
+		}
+
+		blocks = append(blocks, importAndResource("remscontent_category", g.categories[c.CategoryId], c.CategoryId, attributes)...)
+	}
+
+	return blocks, nil
+}
+
+func (g *generator) licenseBlocks(ctx context.Context) ([]block, error) {
+	licenses, httpResp, err := g.client.LicensesAPI.ApiLicensesGet(ctx).Disabled(true).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list licenses: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(licenses, func(i, j int) bool { return licenses[i].Id < licenses[j].Id })
+
+	n := names{}
+
+	var blocks []block
+
+	for _, l := range licenses {
+		if l.Archived {
+			continue
+		}
+
+		titles := map[string]string{}
+		localizations := object{}
+
+		for _, lang := range sortedKeys(l.Localizations) {
+			localization := l.Localizations[lang]
+			titles[lang] = localization.Title
+
+			attributes := object{
+				{Name: "title", Value: stringValue(localization.Title)},
+				{Name: "textcontent", Value: stringValue(localization.Textcontent)},
+			}
+
+			if id := localization.AttachmentId.Get(); id != nil {
+				attributes = append(attributes, attribute{Name: "attachment_id", Value: intValue(*id)})
+			}
+
+			localizations = append(localizations, attribute{Name: lang, Value: attributes})
+		}
+
+		name := n.name(resources.LocalizedText(titles), "license")
+		g.licenses[l.Id] = name
+
+		blocks = append(blocks, importAndResource("remscontent_license", name, l.Id, []attribute{
+			{Name: "organization_id", Value: g.organizationRef(l.Organization.OrganizationId)},
+			{Name: "type", Value: stringValue(l.Licensetype)},
+			{Name: "localizations", Value: localizations},
+		})...)
+	}
+
+	return blocks, nil
+}
+
+func (g *generator) formBlocks(ctx context.Context) ([]block, error) {
+	forms, httpResp, err := g.client.FormsAPI.ApiFormsGet(ctx).Disabled(true).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list forms: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(forms, func(i, j int) bool { return forms[i].FormId < forms[j].FormId })
+
+	n := names{}
+
+	var blocks []block
+
+	for _, overview := range forms {
+		if overview.Archived {
+			continue
+		}
+
+		// the listing leaves out the fields
+		form, httpResp, err := g.client.FormsAPI.ApiFormsFormIdGet(ctx, overview.FormId).Execute()
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read form %d: %s %v", overview.FormId, err.Error(), httpResp)
+		}
+
+		title := form.FormInternalName
+		if form.FormTitle != nil {
+			title = *form.FormTitle
+		}
+
+		fields := list{}
+		for _, f := range form.FormFields {
+			fields = append(fields, formField(f))
+		}
+
+		name := n.name(title, "form")
+		g.forms[form.FormId] = name
+
+		blocks = append(blocks, importAndResource("remscontent_form", name, form.FormId, []attribute{
+			{Name: "organization_id", Value: g.organizationRef(form.Organization.OrganizationId)},
+			{Name: "title", Value: stringValue(title)},
+			{Name: "fields", Value: fields},
+		})...)
+	}
+
+	return blocks, nil
+}
+
+// formField writes a field the way it would be written by hand, leaving out
+// anything that is the REMS default.
+func formField(f remsclient.FieldTemplate) value {
+	field := object{
+		{Name: "id", Value: stringValue(f.FieldId)},
+		{Name: "type", Value: stringValue(f.FieldType)},
+		{Name: "title", Value: stringMap(f.FieldTitle)},
+	}
+
+	if f.FieldInfoText != nil && len(*f.FieldInfoText) > 0 {
+		field = append(field, attribute{Name: "info", Value: stringMap(*f.FieldInfoText)})
+	}
+
+	if f.FieldPlaceholder != nil && len(*f.FieldPlaceholder) > 0 {
+		field = append(field, attribute{Name: "placeholder", Value: stringMap(*f.FieldPlaceholder)})
+	}
+
+	if f.FieldOptional && form_fields.SupportsOptional(f.FieldType) {
+		field = append(field, attribute{Name: "optional", Value: boolValue(true)})
+	}
+
+	if maxLength := f.FieldMaxLength.Get(); maxLength != nil {
+		field = append(field, attribute{Name: "max_length", Value: intValue(*maxLength)})
+	}
+
+	if f.FieldOptions != nil {
+		options := list{}
+		for _, o := range f.FieldOptions {
+			options = append(options, object{
+				{Name: "key", Value: stringValue(o.Key)},
+				{Name: "label", Value: stringMap(o.Label)},
+			})
+		}
+		field = append(field, attribute{Name: "options", Value: options})
+	}
+
+	if f.FieldColumns != nil {
+		columns := list{}
+		for _, c := range f.FieldColumns {
+			columns = append(columns, object{
+				{Name: "key", Value: stringValue(c.Key)},
+				{Name: "label", Value: stringMap(c.Label)},
+			})
+		}
+		field = append(field, attribute{Name: "columns", Value: columns})
+	}
+
+	if f.FieldPrivacy != nil && *f.FieldPrivacy != form_fields.PrivacyPublic && form_fields.SupportsPrivacy(f.FieldType) {
+		field = append(field, attribute{Name: "privacy", Value: stringValue(*f.FieldPrivacy)})
+	}
+
+	if v := f.FieldVisibility; v != nil && v.VisibilityType != form_fields.VisibilityAlways {
+		visibility := object{
+			{Name: "type", Value: stringValue(v.VisibilityType)},
+		}
+		if v.VisibilityField != nil {
+			visibility = append(visibility, attribute{Name: "field_id", Value: stringValue(v.VisibilityField.FieldId)})
+		}
+		if v.VisibilityValues != nil {
+			visibility = append(visibility, attribute{Name: "values", Value: stringList(v.VisibilityValues)})
+		}
+		field = append(field, attribute{Name: "visibility", Value: visibility})
+	}
+
+	return field
+}
+
+func (g *generator) resourceBlocks(ctx context.Context) ([]block, error) {
+	remsResources, httpResp, err := g.client.ResourcesAPI.ApiResourcesGet(ctx).Disabled(true).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list resources: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(remsResources, func(i, j int) bool { return remsResources[i].Id < remsResources[j].Id })
+
+	n := names{}
+
+	var blocks []block
+
+	for _, r := range remsResources {
+		if r.Archived {
+			continue
+		}
+
+		name := n.name(r.Resid, "resource")
+		g.resources[r.Id] = name
+
+		attributes := []attribute{
+			{Name: "organization_id", Value: g.organizationRef(r.Organization.OrganizationId)},
+			{Name: "resid", Value: stringValue(r.Resid)},
+		}
+
+		if len(r.Licenses) > 0 {
+			licenses := list{}
+			for _, l := range r.Licenses {
+				licenses = append(licenses, g.ref("remscontent_license", g.licenses, l.Id))
+			}
+			attributes = append(attributes, attribute{Name: "licenses", Value: licenses})
+		}
+
+		duoCodes, err := duoCodeBlocks(ctx, r.GetResourceDuo().DuoCodes)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read the DUO codes of resource %d: %w", r.Id, err)
+		}
+
+		resourceAndImport := importAndResource("remscontent_resource", name, r.Id, attributes)
+		resourceAndImport[1].Blocks = duoCodes
+
+		blocks = append(blocks, resourceAndImport...)
+	}
+
+	return blocks, nil
+}
+
+// duoCodeBlocks writes the DUO codes of a resource as duo_code blocks, each with
+// the typed restriction a remscontent_resource reads them back as.
+func duoCodeBlocks(ctx context.Context, codes []remsclient.DuoCodeFull) ([]block, error) {
+	var blocks []block
+
+	for _, c := range codes {
+		code := block{
+			Type:       "duo_code",
+			Attributes: []attribute{{Name: "id", Value: stringValue(c.Id)}},
+		}
+
+		if c.MoreInfo != nil && len(*c.MoreInfo) > 0 {
+			code.Attributes = append(code.Attributes, attribute{Name: "more_info", Value: stringMap(*c.MoreInfo)})
+		}
+
+		if len(c.Restrictions) > 0 {
+			restriction, diags := resources.DuoRestrictionValue(ctx, c.Id, c.Restrictions)
+
+			if diags.HasError() {
+				return nil, fmt.Errorf("%v", diags)
+			}
+
+			var attributes []attribute
+
+			if !restriction.Mondo.IsNull() {
+				mondo := []string{}
+				if diags := restriction.Mondo.ElementsAs(ctx, &mondo, false); diags.HasError() {
+					return nil, fmt.Errorf("%v", diags)
+				}
+				attributes = append(attributes, attribute{Name: "mondo", Value: stringList(mondo)})
+			}
+
+			for _, text := range []struct {
+				name  string
+				value types.String
+			}{
+				{"topic", restriction.Topic},
+				{"location", restriction.Location},
+				{"institute", restriction.Institute},
+				{"collaboration", restriction.Collaboration},
+				{"project", restriction.Project},
+				{"users", restriction.Users},
+				{"date", restriction.Date},
+			} {
+				if !text.value.IsNull() {
+					attributes = append(attributes, attribute{Name: text.name, Value: stringValue(text.value.ValueString())})
+				}
+			}
+
+			if !restriction.Months.IsNull() {
+				attributes = append(attributes, attribute{Name: "months", Value: intValue(restriction.Months.ValueInt64())})
+			}
+
+			code.Blocks = []block{{Type: "restriction", Attributes: attributes}}
+		}
+
+		blocks = append(blocks, code)
+	}
+
+	return blocks, nil
+}
+
+func (g *generator) workflowBlocks(ctx context.Context) ([]block, error) {
+	workflows, httpResp, err := g.client.WorkflowsAPI.ApiWorkflowsGet(ctx).Disabled(true).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list workflows: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Id < workflows[j].Id })
+
+	n := names{}
+
+	var blocks []block
+
+	for _, w := range workflows {
+		if w.Archived {
+			continue
+		}
+
+		details := resources.WorkflowDetails(&w)
+
+		name := n.name(w.Title, "workflow")
+		g.workflows[w.Id] = name
+
+		attributes := []attribute{
+			{Name: "organization_id", Value: g.organizationRef(w.Organization.OrganizationId)},
+			{Name: "title", Value: stringValue(w.Title)},
+			{Name: "type", Value: stringValue(details.Type)},
+		}
+
+		if len(details.Handlers) > 0 {
+			attributes = append(attributes, attribute{Name: "handlers", Value: stringList(details.Handlers)})
+		}
+
+		if len(details.Forms) > 0 {
+			forms := list{}
+			for _, id := range details.Forms {
+				forms = append(forms, g.ref("remscontent_form", g.forms, id))
+			}
+			attributes = append(attributes, attribute{Name: "forms", Value: forms})
+		}
+
+		if len(details.Licenses) > 0 {
+			licenses := list{}
+			for _, id := range details.Licenses {
+				licenses = append(licenses, g.ref("remscontent_license", g.licenses, id))
+			}
+			attributes = append(attributes, attribute{Name: "licenses", Value: licenses})
+		}
+
+		blocks = append(blocks, importAndResource("remscontent_workflow", name, w.Id, attributes)...)
+	}
+
+	return blocks, nil
+}
+
+func (g *generator) catalogueItemBlocks(ctx context.Context) ([]block, error) {
+	items, httpResp, err := g.client.CatalogueItemsAPI.ApiCatalogueItemsGet(ctx).Disabled(true).Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list catalogue items: %s %v", err.Error(), httpResp)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Id < items[j].Id })
+
+	n := names{}
+
+	var blocks []block
+
+	for _, item := range items {
+		if item.Archived || item.Expired {
+			continue
+		}
+
+		titles := map[string]string{}
+		localizations := object{}
+
+		for _, lang := range sortedKeys(item.Localizations) {
+			localization := item.Localizations[lang]
+			titles[lang] = localization.Title
+
+			attributes := object{
+				{Name: "title", Value: stringValue(localization.Title)},
+			}
+
+			if infourl := localization.Infourl.Get(); infourl != nil {
+				attributes = append(attributes, attribute{Name: "infourl", Value: stringValue(*infourl)})
+			}
+
+			localizations = append(localizations, attribute{Name: lang, Value: attributes})
+		}
+
+		name := n.name(resources.LocalizedText(titles), "catalogue_item")
+
+		attributes := []attribute{
+			{Name: "organization_id", Value: g.organizationRef(item.Organization.OrganizationId)},
+			{Name: "resource_id", Value: g.ref("remscontent_resource", g.resources, item.ResourceId)},
+			{Name: "workflow_id", Value: g.ref("remscontent_workflow", g.workflows, item.Wfid)},
+		}
+
+		if formId := item.Formid.Get(); formId != nil {
+			attributes = append(attributes, attribute{Name: "form_id", Value: g.ref("remscontent_form", g.forms, *formId)})
+		}
+
+		attributes = append(attributes, attribute{Name: "localizations", Value: localizations})
+
+		if len(item.Categories) > 0 {
+			categories := list{}
+			for _, c := range item.Categories {
+				categories = append(categories, g.ref("remscontent_category", g.categories, c.CategoryId))
+			}
+			attributes = append(attributes, attribute{Name: "categories", Value: categories})
+		}
+
+		blocks = append(blocks, importAndResource("remscontent_catalogue_item", name, item.Id, attributes)...)
+	}
+
+	return blocks, nil
+}
+
+// ref refers to the generated resource for an id, falling back to the literal id
+// for objects that were not generated (such as archived ones).
+func (g *generator) ref(resourceType string, generated map[int64]string, id int64) value {
+	if name, ok := generated[id]; ok {
+		return reference(resourceType, name, "id")
+	}
+	return intValue(id)
+}
+
+func (g *generator) organizationRef(id string) value {
+	if name, ok := g.organizations[id]; ok {
+		return reference("local", "organizations", name)
+	}
+	return stringValue(id)
+}
+
+// importAndResource returns a resource block preceded by the import block that
+// adopts the existing REMS object.
+func importAndResource(resourceType string, name string, id int64, attributes []attribute) []block {
+	return []block{
+		{
+			Type: "import",
+			Attributes: []attribute{
+				{Name: "to", Value: reference(resourceType, name)},
+				{Name: "id", Value: stringValue(strconv.FormatInt(id, 10))},
+			},
+		},
+		{
+			Type:       "resource",
+			Labels:     []string{resourceType, name},
+			Attributes: attributes,
+		},
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
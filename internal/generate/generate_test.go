@@ -0,0 +1,413 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// fakeRemsClient is a client for a REMS server that answers each "METHOD /path"
+// route with canned JSON, and anything else with a 404.
+func fakeRemsClient(t *testing.T, routes map[string]string) *remsclient.APIClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := routes[r.Method+" "+r.URL.Path]
+
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	t.Cleanup(server.Close)
+
+	serverUrl, _ := url.Parse(server.URL)
+
+	cfg := remsclient.NewConfiguration()
+	cfg.Host = serverUrl.Host
+	cfg.Scheme = serverUrl.Scheme
+	cfg.HTTPClient = server.Client()
+
+	return remsclient.NewAPIClient(cfg)
+}
+
+// emptyRems is the routes of a REMS with nothing in it but an organization.
+func emptyRems() map[string]string {
+	return map[string]string{
+		"GET /api/organizations":   `[{"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}}]`,
+		"GET /api/categories":      `[]`,
+		"GET /api/licenses":        `[]`,
+		"GET /api/forms":           `[]`,
+		"GET /api/resources":       `[]`,
+		"GET /api/workflows":       `[]`,
+		"GET /api/catalogue-items": `[]`,
+	}
+}
+
+// requireRoundTrip imports each generated resource into a fresh state, reads it
+// from REMS and fails the test for any attribute the configuration would change -
+// that is, anything the first plan would show other than the imports.
+func requireRoundTrip(t *testing.T, client *remsclient.APIClient, sections []section) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	constructors := map[string]func() resource.Resource{}
+	for _, newResource := range provider.New("test")().Resources(ctx) {
+		var metadata resource.MetadataResponse
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "remscontent"}, &metadata)
+		constructors[metadata.TypeName] = newResource
+	}
+
+	// references are resolved to the values they will have once imported
+	refs := map[string]value{}
+	imports := map[string]string{}
+
+	for _, s := range sections {
+		for _, bl := range s.blocks {
+			for _, a := range bl.Attributes {
+				switch {
+				case bl.Type == "locals" && a.Name == "organizations":
+					for _, o := range a.Value.(object) {
+						refs["local.organizations."+o.Name] = o.Value
+					}
+				case bl.Type == "import" && a.Name == "to":
+					to := string(a.Value.(literal))
+					id := unquote(t, string(bl.Attributes[1].Value.(literal)))
+					refs[to+".id"] = literal(id)
+					imports[to] = id
+				}
+			}
+		}
+	}
+
+	for _, s := range sections {
+		for _, bl := range s.blocks {
+			if bl.Type != "resource" {
+				continue
+			}
+
+			address := strings.Join(bl.Labels, ".")
+
+			newResource, ok := constructors[bl.Labels[0]]
+			require.True(t, ok, "%s is not a resource of the provider", address)
+
+			r := newResource()
+
+			var configureResp resource.ConfigureResponse
+			r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &configureResp)
+			require.False(t, configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			var identitySchemaResp resource.IdentitySchemaResponse
+			r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)}
+			identity := &tfsdk.ResourceIdentity{
+				Schema: identitySchemaResp.IdentitySchema,
+				Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+			}
+
+			importResp := resource.ImportStateResponse{State: state, Identity: identity}
+			r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: imports[address]}, &importResp)
+			require.False(t, importResp.Diagnostics.HasError(), "%s: %v", address, importResp.Diagnostics)
+
+			readResp := resource.ReadResponse{State: importResp.State, Identity: importResp.Identity}
+			r.Read(ctx, resource.ReadRequest{State: importResp.State, Identity: importResp.Identity}, &readResp)
+			require.False(t, readResp.Diagnostics.HasError(), "%s: %v", address, readResp.Diagnostics)
+
+			var config, imported map[string]tftypes.Value
+			require.NoError(t, blockValue(t, bl, schemaType.(tftypes.Object), schemaResp.Schema.Blocks, refs).As(&config))
+			require.NoError(t, readResp.State.Raw.As(&imported))
+
+			for name := range config {
+				// computed attributes left out of the configuration keep their state
+				if a, ok := schemaResp.Schema.Attributes[name]; ok && a.IsComputed() && config[name].IsNull() {
+					continue
+				}
+
+				diffs, err := imported[name].Diff(config[name])
+				require.NoError(t, err)
+
+				for _, diff := range diffs {
+					t.Errorf("%s.%s: %s would change from %s to %s", address, name, diff.Path, diff.Value1, diff.Value2)
+				}
+			}
+		}
+	}
+}
+
+// blockValue is the value of a generated block as Terraform would see it, given
+// the schema of the blocks nested in it: each list block is a list of the blocks
+// of its type, and a single block that is left out is null.
+func blockValue(t *testing.T, bl block, typ tftypes.Object, blocks map[string]schema.Block, refs map[string]value) tftypes.Value {
+	t.Helper()
+
+	var values map[string]tftypes.Value
+	require.NoError(t, terraformValue(t, object(bl.Attributes), typ, refs).As(&values))
+
+	for _, nested := range bl.Blocks {
+		_, ok := blocks[nested.Type]
+		require.True(t, ok, "unexpected block %s", nested.Type)
+	}
+
+	for name, nestedSchema := range blocks {
+		var found []block
+		for _, nested := range bl.Blocks {
+			if nested.Type == name {
+				found = append(found, nested)
+			}
+		}
+
+		switch nestedSchema := nestedSchema.(type) {
+		case schema.ListNestedBlock:
+			listType := typ.AttributeTypes[name].(tftypes.List)
+			items := []tftypes.Value{}
+			for _, nested := range found {
+				items = append(items, blockValue(t, nested, listType.ElementType.(tftypes.Object), nestedSchema.NestedObject.Blocks, refs))
+			}
+			values[name] = tftypes.NewValue(listType, items)
+
+		case schema.SingleNestedBlock:
+			require.LessOrEqual(t, len(found), 1, "more than one %s block", name)
+			values[name] = tftypes.NewValue(typ.AttributeTypes[name], nil)
+			if len(found) == 1 {
+				values[name] = blockValue(t, found[0], typ.AttributeTypes[name].(tftypes.Object), nestedSchema.Blocks, refs)
+			}
+
+		default:
+			t.Fatalf("unexpected kind of block %s", name)
+		}
+	}
+
+	return tftypes.NewValue(typ, values)
+}
+
+// terraformValue is the value of a generated expression as Terraform would see it.
+func terraformValue(t *testing.T, v value, typ tftypes.Type, refs map[string]value) tftypes.Value {
+	t.Helper()
+
+	if l, ok := v.(literal); ok {
+		if resolved, ok := refs[string(l)]; ok {
+			return terraformValue(t, resolved, typ, refs)
+		}
+	}
+
+	switch {
+	case typ.Is(tftypes.Object{}):
+		objectType := typ.(tftypes.Object)
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for _, a := range v.(object) {
+			attributeType, ok := objectType.AttributeTypes[a.Name]
+			require.True(t, ok, "unexpected attribute %s", a.Name)
+			values[a.Name] = terraformValue(t, a.Value, attributeType, refs)
+		}
+		return tftypes.NewValue(typ, values)
+
+	case typ.Is(tftypes.Map{}):
+		elementType := typ.(tftypes.Map).ElementType
+		values := map[string]tftypes.Value{}
+		for _, a := range v.(object) {
+			values[a.Name] = terraformValue(t, a.Value, elementType, refs)
+		}
+		return tftypes.NewValue(typ, values)
+
+	case typ.Is(tftypes.List{}):
+		elementType := typ.(tftypes.List).ElementType
+		values := []tftypes.Value{}
+		for _, item := range v.(list) {
+			values = append(values, terraformValue(t, item, elementType, refs))
+		}
+		return tftypes.NewValue(typ, values)
+
+	case typ.Is(tftypes.Set{}):
+		elementType := typ.(tftypes.Set).ElementType
+		values := []tftypes.Value{}
+		for _, item := range v.(list) {
+			values = append(values, terraformValue(t, item, elementType, refs))
+		}
+		return tftypes.NewValue(typ, values)
+
+	case typ.Is(tftypes.String):
+		return tftypes.NewValue(typ, unquote(t, string(v.(literal))))
+
+	case typ.Is(tftypes.Number):
+		n, ok := new(big.Float).SetString(string(v.(literal)))
+		require.True(t, ok, "%s is not a number", v)
+		return tftypes.NewValue(typ, n)
+
+	case typ.Is(tftypes.Bool):
+		b, err := strconv.ParseBool(string(v.(literal)))
+		require.NoError(t, err)
+		return tftypes.NewValue(typ, b)
+	}
+
+	t.Fatalf("unexpected type %s", typ)
+
+	return tftypes.Value{}
+}
+
+// unquote reads back a string written by quote.
+func unquote(t *testing.T, s string) string {
+	t.Helper()
+
+	unquoted, err := strconv.Unquote(s)
+	require.NoError(t, err, "%s is not a string", s)
+
+	return strings.NewReplacer("$${", "${", "%%{", "%{").Replace(unquoted)
+}
+
+func TestGenerateFormRoundTrip(t *testing.T) {
+	routes := emptyRems()
+
+	routes["GET /api/forms"] = `[{
+	"form/id": 3,
+	"organization": {"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}},
+	"form/internal-name": "Intake",
+	"form/external-title": {"en": "Intake"},
+	"enabled": true,
+	"archived": false
+}]`
+	routes["GET /api/forms/3"] = `{
+	"form/id": 3,
+	"organization": {"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}},
+	"form/internal-name": "Intake",
+	"form/title": "Intake",
+	"form/external-title": {"en": "Intake"},
+	"form/fields": [
+		{"field/id": "fld1", "field/type": "header", "field/title": {"en": "About you"}, "field/optional": false, "field/visibility": {"visibility/type": "always"}},
+		{"field/id": "fld2", "field/type": "text", "field/title": {"en": "Name"}, "field/optional": false, "field/info-text": {}, "field/placeholder": {}, "field/privacy": "public", "field/visibility": {"visibility/type": "always"}},
+		{"field/id": "fld3", "field/type": "option", "field/title": {"en": "Clinical?"}, "field/optional": true, "field/privacy": "private",
+			"field/options": [{"key": "yes", "label": {"en": "Yes"}}, {"key": "no", "label": {"en": "No"}}], "field/visibility": {"visibility/type": "always"}},
+		{"field/id": "fld4", "field/type": "texta", "field/title": {"en": "Ethics approval"}, "field/optional": false, "field/max-length": 200,
+			"field/info-text": {"en": "As granted by the HREC"}, "field/privacy": "public",
+			"field/visibility": {"visibility/type": "only-if", "visibility/field": {"field/id": "fld3"}, "visibility/values": ["yes"]}}
+	],
+	"enabled": true,
+	"archived": false
+}`
+
+	client := fakeRemsClient(t, routes)
+
+	sections, err := generateSections(context.Background(), client)
+	require.NoError(t, err)
+
+	requireRoundTrip(t, client, sections)
+
+	files, err := Generate(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// the defaults REMS filled in are left out, as they would be by hand
+	assert.Equal(t, "forms.tf", files[1].Name)
+	assert.NotContains(t, files[1].Content, "optional = false")
+	assert.NotContains(t, files[1].Content, `"public"`)
+	assert.NotContains(t, files[1].Content, `"always"`)
+}
+
+func TestGenerateResourceRoundTrip(t *testing.T) {
+	routes := emptyRems()
+
+	resource := `{
+	"id": 12,
+	"organization": {"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}},
+	"resid": "urn:example:dataset:1",
+	"enabled": true,
+	"archived": false,
+	"licenses": [],
+	"resource/duo": {"duo/codes": [
+		{"id": "DUO:0000007", "label": {"en": "disease specific research"}, "description": {"en": ""},
+			"more-info": {"en": "Cancers only"},
+			"restrictions": [{"type": "mondo", "values": [{"id": "MONDO:0004992", "label": "cancer"}, {"id": "MONDO:0005070"}]}]},
+		{"id": "DUO:0000022", "label": {"en": "geographical restriction"}, "description": {"en": ""},
+			"restrictions": [{"type": "location", "values": [{"value": "Australia"}]}]},
+		{"id": "DUO:0000024", "label": {"en": "publication moratorium"}, "description": {"en": ""},
+			"restrictions": [{"type": "months", "values": [{"value": 12}]}]},
+		{"id": "DUO:0000042", "label": {"en": "general research use"}, "description": {"en": ""}, "more-info": {}}
+	]}
+}`
+
+	routes["GET /api/resources"] = "[" + resource + "]"
+	routes["GET /api/resources/12"] = resource
+
+	client := fakeRemsClient(t, routes)
+
+	sections, err := generateSections(context.Background(), client)
+	require.NoError(t, err)
+
+	requireRoundTrip(t, client, sections)
+
+	files, err := Generate(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "resources.tf", files[1].Name)
+	assert.Equal(t, `# Generated by terraform-provider-remscontent generate
+
+import {
+  to = remscontent_resource.urn_example_dataset_1
+  id = "12"
+}
+
+resource "remscontent_resource" "urn_example_dataset_1" {
+  organization_id = local.organizations.umccr
+  resid           = "urn:example:dataset:1"
+
+  duo_code {
+    id = "DUO:0000007"
+    more_info = {
+      en = "Cancers only"
+    }
+
+    restriction {
+      mondo = ["MONDO:0004992", "MONDO:0005070"]
+    }
+  }
+
+  duo_code {
+    id = "DUO:0000022"
+
+    restriction {
+      location = "Australia"
+    }
+  }
+
+  duo_code {
+    id = "DUO:0000024"
+
+    restriction {
+      months = 12
+    }
+  }
+
+  duo_code {
+    id = "DUO:0000042"
+  }
+}
+`, files[1].Content)
+}
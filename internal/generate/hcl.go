@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A deliberately small HCL writer - just enough to lay out the blocks we generate
+// the way `terraform fmt` would.
+
+// value is an HCL expression.
+type value interface {
+	write(b *strings.Builder, indent int)
+}
+
+// attribute is a name = value pair of a block or object.
+type attribute struct {
+	Name  string
+	Value value
+}
+
+// block is a block such as resource "type" "name" { ... }, which may hold
+// blocks of its own after its attributes.
+type block struct {
+	Type       string
+	Labels     []string
+	Attributes []attribute
+	Blocks     []block
+}
+
+// literal is an already rendered expression, such as a number or a reference.
+type literal string
+
+// list is a tuple of values.
+type list []value
+
+// object is an object of values in the given order.
+type object []attribute
+
+func stringValue(s string) value {
+	return literal(quote(s))
+}
+
+func intValue(i int64) value {
+	return literal(strconv.FormatInt(i, 10))
+}
+
+func boolValue(b bool) value {
+	return literal(strconv.FormatBool(b))
+}
+
+// reference is a traversal such as remscontent_form.x.id.
+func reference(parts ...string) value {
+	return literal(strings.Join(parts, "."))
+}
+
+// stringMap renders a map of strings with its keys in order.
+func stringMap(m map[string]string) value {
+	o := object{}
+	for _, k := range sortedKeys(m) {
+		o = append(o, attribute{Name: k, Value: stringValue(m[k])})
+	}
+	return o
+}
+
+func stringList(items []string) value {
+	l := list{}
+	for _, item := range items {
+		l = append(l, stringValue(item))
+	}
+	return l
+}
+
+func (l literal) write(b *strings.Builder, indent int) {
+	b.WriteString(string(l))
+}
+
+func (l list) write(b *strings.Builder, indent int) {
+	if len(l) == 0 {
+		b.WriteString("[]")
+		return
+	}
+
+	// lists of plain values fit on one line, anything nested gets a line per element
+	if !multiline(l) {
+		b.WriteString("[")
+		for i, v := range l {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			v.write(b, indent)
+		}
+		b.WriteString("]")
+		return
+	}
+
+	b.WriteString("[\n")
+	for _, v := range l {
+		writeIndent(b, indent+1)
+		v.write(b, indent+1)
+		b.WriteString(",\n")
+	}
+	writeIndent(b, indent)
+	b.WriteString("]")
+}
+
+func (o object) write(b *strings.Builder, indent int) {
+	if len(o) == 0 {
+		b.WriteString("{}")
+		return
+	}
+
+	b.WriteString("{\n")
+	writeAttributes(b, indent+1, o)
+	writeIndent(b, indent)
+	b.WriteString("}")
+}
+
+func (bl block) write(b *strings.Builder) {
+	bl.writeIndented(b, 0)
+}
+
+// writeIndented writes the block with its nested blocks set apart from its
+// attributes, and from each other, by a blank line.
+func (bl block) writeIndented(b *strings.Builder, indent int) {
+	writeIndent(b, indent)
+	b.WriteString(bl.Type)
+	for _, label := range bl.Labels {
+		b.WriteString(" ")
+		b.WriteString(quote(label))
+	}
+	b.WriteString(" {\n")
+	writeAttributes(b, indent+1, bl.Attributes)
+	for i, nested := range bl.Blocks {
+		if i > 0 || len(bl.Attributes) > 0 {
+			b.WriteString("\n")
+		}
+		nested.writeIndented(b, indent+1)
+	}
+	writeIndent(b, indent)
+	b.WriteString("}\n")
+}
+
+// writeAttributes writes one attribute per line, aligning the equals signs of
+// neighbouring attributes as terraform fmt does. A value spanning several lines
+// is not aligned and ends the run of aligned attributes.
+func writeAttributes(b *strings.Builder, indent int, attributes []attribute) {
+	for i := 0; i < len(attributes); {
+		j := i + 1
+		width := len(attributeName(attributes[i].Name))
+
+		if !multiline(attributes[i].Value) {
+			for j < len(attributes) && !multiline(attributes[j].Value) {
+				if n := len(attributeName(attributes[j].Name)); n > width {
+					width = n
+				}
+				j++
+			}
+		}
+
+		for _, a := range attributes[i:j] {
+			name := attributeName(a.Name)
+			writeIndent(b, indent)
+			b.WriteString(name)
+			b.WriteString(strings.Repeat(" ", width-len(name)))
+			b.WriteString(" = ")
+			a.Value.write(b, indent)
+			b.WriteString("\n")
+		}
+
+		i = j
+	}
+}
+
+func multiline(v value) bool {
+	switch v := v.(type) {
+	case object:
+		return len(v) > 0
+	case list:
+		for _, item := range v {
+			if _, ok := item.(literal); !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeIndent(b *strings.Builder, indent int) {
+	b.WriteString(strings.Repeat("  ", indent))
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// attributeName quotes object keys that are not valid identifiers.
+func attributeName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return quote(name)
+}
+
+// quote renders a string literal using only the escapes HCL understands, and
+// escapes template sequences so that text such as "${name}" in a REMS
+// description comes through unchanged.
+func quote(s string) string {
+	var b strings.Builder
+
+	b.WriteString(`"`)
+	for i, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r) && r > 0xffff:
+			fmt.Fprintf(&b, `\U%08x`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(`"`)
+
+	return b.String()
+}
+
+var nonNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// names hands out unique Terraform resource names derived from REMS titles.
+type names map[string]bool
+
+func (n names) name(title string, fallback string) string {
+	base := strings.Trim(nonNamePattern.ReplaceAllString(strings.ToLower(title), "_"), "_")
+
+	if base == "" {
+		base = fallback
+	}
+
+	// names must not start with a digit
+	if base[0] >= '0' && base[0] <= '9' {
+		base = fallback + "_" + base
+	}
+
+	name := base
+	for i := 2; n[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	n[name] = true
+
+	return name
+}
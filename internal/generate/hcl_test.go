@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuote(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"plain":                  {`Melanoma cohort`, `"Melanoma cohort"`},
+		"empty":                  {``, `""`},
+		"quotes and backslashes": {`say "hi" \o/`, `"say \"hi\" \\o/"`},
+		"whitespace escapes":     {"a\nb\r\tc", `"a\nb\r\tc"`},
+		"interpolation":          {`${name}`, `"$${name}"`},
+		"directive":              {`%{if x}`, `"%%{if x}"`},
+		// HCL reads this back as a lone "$" followed by the escaped "${"
+		"already escaped":         {`$${name}`, `"$$${name}"`},
+		"dollar without brace":    {`costs $5 or 5%`, `"costs $5 or 5%"`},
+		"brace without dollar":    {`{"a": 1}`, `"{\"a\": 1}"`},
+		"template at the end":     {`ends with $`, `"ends with $"`},
+		"control character":       {"bell\a", `"bell\u0007"`},
+		"non printable astral":    {"tag\U000E0041", `"tag\U000e0041"`},
+		"printable unicode as is": {`Melanoomakohortti – ä 🧬`, `"Melanoomakohortti – ä 🧬"`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, quote(test.input))
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := map[string]struct {
+		titles   []string
+		expected []string
+	}{
+		"lower cased and joined": {
+			titles:   []string{"Access to XYZ data"},
+			expected: []string{"access_to_xyz_data"},
+		},
+		"punctuation collapsed and trimmed": {
+			titles:   []string{"  MGRB -- (2024)!  "},
+			expected: []string{"mgrb_2024"},
+		},
+		"nothing usable falls back": {
+			titles:   []string{"", "☃☃", "Ω"},
+			expected: []string{"form", "form_2", "form_3"},
+		},
+		"leading digit prefixed": {
+			titles:   []string{"2024 cohort", "1"},
+			expected: []string{"form_2024_cohort", "form_1"},
+		},
+		"collisions suffixed": {
+			titles:   []string{"Cohort", "cohort", "COHORT!"},
+			expected: []string{"cohort", "cohort_2", "cohort_3"},
+		},
+		"suffix clashing with a later title": {
+			titles:   []string{"Cohort", "Cohort", "Cohort 2"},
+			expected: []string{"cohort", "cohort_2", "cohort_2_2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			n := names{}
+			actual := []string{}
+			for _, title := range test.titles {
+				actual = append(actual, n.name(title, "form"))
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestAttributeName(t *testing.T) {
+	tests := map[string]string{
+		"en":          "en",
+		"resource_id": "resource_id",
+		"pt-BR":       "pt-BR",
+		"1st":         `"1st"`,
+		"has space":   `"has space"`,
+		"":            `""`,
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, expected, attributeName(input))
+		})
+	}
+}
+
+func TestBlockWrite(t *testing.T) {
+	var b strings.Builder
+
+	block{
+		Type:   "resource",
+		Labels: []string{"remscontent_catalogue_item", "cohort"},
+		Attributes: []attribute{
+			{Name: "organization_id", Value: stringValue("umccr")},
+			{Name: "form_id", Value: reference("remscontent_form", "cohort", "id")},
+			{Name: "localizations", Value: object{
+				{Name: "en", Value: object{{Name: "title", Value: stringValue("Cohort")}}},
+			}},
+			{Name: "categories", Value: list{intValue(2), intValue(3)}},
+			{Name: "enabled", Value: boolValue(true)},
+		},
+	}.write(&b)
+
+	assert.Equal(t, `resource "remscontent_catalogue_item" "cohort" {
+  organization_id = "umccr"
+  form_id         = remscontent_form.cohort.id
+  localizations = {
+    en = {
+      title = "Cohort"
+    }
+  }
+  categories = [2, 3]
+  enabled    = true
+}
+`, b.String())
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package actions
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// actionClient is the Configure of every action.
+func actionClient(req action.ConfigureRequest, resp *action.ConfigureResponse) *remsclient.APIClient {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+
+	return client
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/applications"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &CloseStaleDraftsAction{}
+var _ action.ActionWithConfigure = &CloseStaleDraftsAction{}
+var _ action.ActionWithValidateConfig = &CloseStaleDraftsAction{}
+
+func NewCloseStaleDraftsAction() action.Action {
+	return &CloseStaleDraftsAction{}
+}
+
+// CloseStaleDraftsAction closes the draft applications nobody has touched in a while.
+type CloseStaleDraftsAction struct {
+	client *remsclient.APIClient
+}
+
+// CloseStaleDraftsActionModel describes the action data model.
+type CloseStaleDraftsActionModel struct {
+	OlderThanDays types.Int64  `tfsdk:"older_than_days"`
+	Comment       types.String `tfsdk:"comment"`
+}
+
+func (a *CloseStaleDraftsAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_close_stale_drafts"
+}
+
+func (a *CloseStaleDraftsAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Closes the draft applications with no activity for a number of days. Only drafts the API user handles are closed.",
+
+		Attributes: map[string]schema.Attribute{
+			"older_than_days": schema.Int64Attribute{
+				MarkdownDescription: "Days without activity after which a draft is stale",
+				Required:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Reason for closing, shown to the applicants",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *CloseStaleDraftsAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var data CloseStaleDraftsActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.OlderThanDays.IsNull() || data.OlderThanDays.IsUnknown() {
+		return
+	}
+
+	if data.OlderThanDays.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("older_than_days"),
+			"Invalid number of days",
+			"A draft must be inactive for at least a day to be stale.",
+		)
+	}
+}
+
+func (a *CloseStaleDraftsAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = actionClient(req, resp)
+}
+
+func (a *CloseStaleDraftsAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data CloseStaleDraftsActionModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	drafts, draftsResponse, draftsErr := a.client.ApplicationsAPI.
+		ApiApplicationsGet(context.Background()).
+		Execute()
+
+	if draftsErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to list applications",
+			fmt.Sprintf("Could not list applications: %s %v", draftsErr.Error(), draftsResponse),
+		)
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -int(data.OlderThanDays.ValueInt64()))
+	closed := 0
+
+	for _, d := range drafts {
+		if d.ApplicationState != applications.StateDraft || !d.ApplicationLastActivity.Before(cutoff) {
+			continue
+		}
+
+		// drafts of workflows the API user does not handle are not ours to close
+		if !slices.Contains(d.ApplicationPermissions, applications.CommandClose) {
+			continue
+		}
+
+		closeCommand := remsclient.NewCloseCommand(d.ApplicationId)
+		closeCommand.Comment = data.Comment.ValueStringPointer()
+
+		result, httpResp, err := a.client.ApplicationsAPI.
+			ApiApplicationsClosePost(context.Background()).
+			CloseCommand(*closeCommand).
+			Execute()
+
+		resp.Diagnostics.Append(applications.CommandDiagnostics("close", d.ApplicationId, result, httpResp, err)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		closed++
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Closed draft application %s (last activity %s)", d.ApplicationExternalId, d.ApplicationLastActivity.Format(time.RFC3339)),
+		})
+	}
+
+	tflog.Trace(ctx, "closed stale drafts", map[string]interface{}{"closed": closed})
+}
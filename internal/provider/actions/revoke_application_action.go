@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package actions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/applications"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &RevokeApplicationAction{}
+var _ action.ActionWithConfigure = &RevokeApplicationAction{}
+
+func NewRevokeApplicationAction() action.Action {
+	return &RevokeApplicationAction{}
+}
+
+// RevokeApplicationAction revokes an approved application, ending its entitlements.
+type RevokeApplicationAction struct {
+	client *remsclient.APIClient
+}
+
+// RevokeApplicationActionModel describes the action data model.
+type RevokeApplicationActionModel struct {
+	ApplicationId types.Int64  `tfsdk:"application_id"`
+	Comment       types.String `tfsdk:"comment"`
+}
+
+func (a *RevokeApplicationAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_revoke_application"
+}
+
+func (a *RevokeApplicationAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Revokes an approved application, ending its entitlements and blacklisting its members for its resources",
+
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the application",
+				Required:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Reason for revoking, shown to the applicant",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *RevokeApplicationAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = actionClient(req, resp)
+}
+
+func (a *RevokeApplicationAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data RevokeApplicationActionModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revokeCommand := remsclient.NewRevokeCommand(data.ApplicationId.ValueInt64())
+	revokeCommand.Comment = data.Comment.ValueStringPointer()
+
+	result, httpResp, err := a.client.ApplicationsAPI.
+		ApiApplicationsRevokePost(context.Background()).
+		RevokeCommand(*revokeCommand).
+		Execute()
+
+	resp.Diagnostics.Append(applications.CommandDiagnostics("revoke", data.ApplicationId.ValueInt64(), result, httpResp, err)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "revoked an application", map[string]interface{}{"application": data.ApplicationId.ValueInt64()})
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &SendRemindersAction{}
+var _ action.ActionWithConfigure = &SendRemindersAction{}
+var _ action.ActionWithValidateConfig = &SendRemindersAction{}
+
+func NewSendRemindersAction() action.Action {
+	return &SendRemindersAction{}
+}
+
+// SendRemindersAction has REMS email reminders about applications waiting on someone.
+type SendRemindersAction struct {
+	client *remsclient.APIClient
+}
+
+// SendRemindersActionModel describes the action data model.
+type SendRemindersActionModel struct {
+	Recipients types.String `tfsdk:"recipients"`
+}
+
+const (
+	remindersAll       = "all"
+	remindersHandlers  = "handlers"
+	remindersReviewers = "reviewers"
+)
+
+func (a *SendRemindersAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_send_reminders"
+}
+
+func (a *SendRemindersAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends the email reminders of REMS about applications waiting on handlers or reviewers",
+
+		Attributes: map[string]schema.Attribute{
+			"recipients": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Who to remind - `%s` (the default), `%s` of open applications or `%s` of applications pending review",
+					remindersAll, remindersHandlers, remindersReviewers),
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (a *SendRemindersAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var data SendRemindersActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Recipients.IsNull() || data.Recipients.IsUnknown() {
+		return
+	}
+
+	switch data.Recipients.ValueString() {
+	case remindersAll, remindersHandlers, remindersReviewers:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("recipients"),
+			"Invalid recipients",
+			fmt.Sprintf("Recipients must be one of %q, %q or %q.", remindersAll, remindersHandlers, remindersReviewers),
+		)
+	}
+}
+
+func (a *SendRemindersAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = actionClient(req, resp)
+}
+
+func (a *SendRemindersAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data SendRemindersActionModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recipients := remindersAll
+	if !data.Recipients.IsNull() {
+		recipients = data.Recipients.ValueString()
+	}
+
+	var httpResp *http.Response
+	var err error
+
+	switch recipients {
+	case remindersHandlers:
+		httpResp, err = a.client.EmailAPI.ApiEmailSendHandlerReminderPost(context.Background()).Execute()
+	case remindersReviewers:
+		httpResp, err = a.client.EmailAPI.ApiEmailSendReviewerReminderPost(context.Background()).Execute()
+	default:
+		httpResp, err = a.client.EmailAPI.ApiEmailSendRemindersPost(context.Background()).Execute()
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failure to send reminders",
+			fmt.Sprintf("Could not send reminders to %s: %s %v", recipients, err.Error(), httpResp),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "sent reminders", map[string]interface{}{"recipients": recipients})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package applications holds what the resources, ephemeral resources and actions
+// that run REMS application commands share.
+package applications
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// States of an application.
+const (
+	StateDraft     = "application.state/draft"
+	StateSubmitted = "application.state/submitted"
+	StateApproved  = "application.state/approved"
+	StateReturned  = "application.state/returned"
+	StateRejected  = "application.state/rejected"
+	StateRevoked   = "application.state/revoked"
+	StateClosed    = "application.state/closed"
+)
+
+// IsOpen is whether an application in the given state is still in progress.
+func IsOpen(state string) bool {
+	return state == StateDraft || state == StateSubmitted || state == StateReturned
+}
+
+// Commands as listed in the permissions of an application.
+const (
+	CommandClose  = "application.command/close"
+	CommandRevoke = "application.command/revoke"
+)
+
+// CommandDiagnostics reports the failure of the command verb on an application.
+func CommandDiagnostics(verb string, applicationId int64, result *remsclient.SuccessResponse, httpResp *http.Response, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Failure to %s application", verb),
+			fmt.Sprintf("Could not %s application %d: %s %v", verb, applicationId, err.Error(), httpResp),
+		)
+		return diags
+	}
+
+	if !result.Success {
+		diags.AddError(
+			fmt.Sprintf("Failure to %s application", verb),
+			fmt.Sprintf("Could not %s application %d: %v", verb, applicationId, result.GetErrors()),
+		)
+	}
+
+	return diags
+}
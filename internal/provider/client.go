@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+
+	remsclient "github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// NewClient configures a client to hit the authenticated endpoint of a REMS
+// instance. It is shared by the provider and the generate command.
+//
+// The default headers hold no Content-Type: the generated client sets the
+// right one for each request, and a default would be sent alongside it (a
+// multipart upload would claim to be JSON as well).
+func NewClient(endpoint string, apiUser string, apiKey string) *remsclient.APIClient {
+	cfg := remsclient.NewConfiguration()
+	cfg.Host = endpoint
+	cfg.Scheme = "https"
+	cfg.DefaultHeader = map[string]string{
+		"x-rems-user-id": apiUser,
+		"x-rems-api-key": apiKey,
+	}
+
+	transport := &requestHeaderRoundTripper{
+		DefaultHeader: cfg.DefaultHeader,
+		Base:          http.DefaultTransport,
+	}
+
+	cfg.HTTPClient = &http.Client{
+		Transport: transport,
+	}
+
+	return remsclient.NewAPIClient(cfg)
+}
+
+// requestHeaderRoundTripper lets a header given to a single request win over the
+// default header of the same name - such as x-rems-user-id when acting as
+// another user. The generated client adds the default alongside the value of
+// the request rather than replacing it, so REMS would otherwise be sent both.
+// The value of the request is kept under the name it was given (not
+// canonicalised) and the default under the canonical name.
+type requestHeaderRoundTripper struct {
+	DefaultHeader map[string]string
+	Base          http.RoundTripper
+}
+
+func (t *requestHeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	cloned := false
+
+	for name := range t.DefaultHeader {
+		canonical := http.CanonicalHeaderKey(name)
+
+		if _, ok := req.Header[canonical]; !ok {
+			continue
+		}
+
+		for key := range req.Header {
+			if key == canonical || !strings.EqualFold(key, canonical) {
+				continue
+			}
+
+			// a round tripper must not change the request it is given
+			if !cloned {
+				req = req.Clone(req.Context())
+				cloned = true
+			}
+
+			delete(req.Header, canonical)
+			break
+		}
+	}
+
+	return t.Base.RoundTrip(req)
+}
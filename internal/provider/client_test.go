@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// newTestClient is a client made by NewClient pointing at a server that records
+// the headers of each request.
+func newTestClient(t *testing.T) (*remsclient.APIClient, map[string]http.Header) {
+	headers := map[string]http.Header{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers[r.Method+" "+r.URL.Path] = r.Header.Clone()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	serverUrl, _ := url.Parse(server.URL)
+
+	client := NewClient(serverUrl.Host, "owner", "secret")
+	client.GetConfig().Scheme = serverUrl.Scheme
+
+	return client, headers
+}
+
+func TestNewClientJsonContentType(t *testing.T) {
+	client, headers := newTestClient(t)
+
+	_, _, err := client.FormsAPI.ApiFormsCreatePost(context.Background()).
+		CreateFormCommand(*remsclient.NewCreateFormCommand(*remsclient.NewOrganizationId("umccr"), []remsclient.NewwFieldTemplate{})).
+		Execute()
+	require.NoError(t, err)
+
+	_, _, err = client.FormsAPI.ApiFormsEnabledPut(context.Background()).
+		EnabledCommand(*remsclient.NewEnabledCommand(1, true)).
+		Execute()
+	require.NoError(t, err)
+
+	for _, route := range []string{"POST /api/forms/create", "PUT /api/forms/enabled"} {
+		require.Contains(t, headers, route)
+		assert.Equal(t, []string{"application/json"}, headers[route].Values("Content-Type"), route)
+		assert.Equal(t, []string{"owner"}, headers[route].Values("x-rems-user-id"), route)
+		assert.Equal(t, []string{"secret"}, headers[route].Values("x-rems-api-key"), route)
+	}
+}
+
+func TestNewClientRequestHeaderWins(t *testing.T) {
+	client, headers := newTestClient(t)
+
+	_, _, err := client.FormsAPI.ApiFormsEnabledPut(context.Background()).
+		EnabledCommand(*remsclient.NewEnabledCommand(1, true)).
+		XRemsUserId("alice").
+		Execute()
+	require.NoError(t, err)
+
+	route := "PUT /api/forms/enabled"
+	require.Contains(t, headers, route)
+	assert.Equal(t, []string{"alice"}, headers[route].Values("x-rems-user-id"))
+	assert.Equal(t, []string{"secret"}, headers[route].Values("x-rems-api-key"))
+}
@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/applications"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationsDataSource{}
+
+func NewApplicationsDataSource() datasource.DataSource {
+	return &ApplicationsDataSource{}
+}
+
+// ApplicationsDataSource defines the data source implementation.
+type ApplicationsDataSource struct {
+	client *remsclient.APIClient
+}
+
+// ApplicationsDataSourceModel describes the data source data model.
+type ApplicationsDataSourceModel struct {
+	Query        types.String                 `tfsdk:"query"`
+	Handled      types.Bool                   `tfsdk:"handled"`
+	Total        types.Int64                  `tfsdk:"total"`
+	HandledTotal types.Int64                  `tfsdk:"handled_total"`
+	Applications []ApplicationDataSourceModel `tfsdk:"applications"`
+}
+
+type ApplicationDataSourceModel struct {
+	Id               types.Int64    `tfsdk:"id"`
+	ExternalId       types.String   `tfsdk:"external_id"`
+	State            types.String   `tfsdk:"state"`
+	Open             types.Bool     `tfsdk:"open"`
+	Applicant        types.String   `tfsdk:"applicant"`
+	WorkflowId       types.Int64    `tfsdk:"workflow_id"`
+	CatalogueItemIds []types.Int64  `tfsdk:"catalogue_item_ids"`
+	ResourceIds      []types.Int64  `tfsdk:"resource_ids"`
+	Resids           []types.String `tfsdk:"resids"`
+	LastActivity     types.String   `tfsdk:"last_activity"`
+}
+
+func (d *ApplicationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications"
+}
+
+func (d *ApplicationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The applications the API user can see, or those it has handled",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "REMS search query the applications must match, such as `resource:\"urn:example:dataset:1\"`",
+				Optional:            true,
+			},
+			"handled": schema.BoolAttribute{
+				MarkdownDescription: "Return the applications the API user has handled (no longer open) instead",
+				Optional:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "Number of applications returned",
+				Computed:            true,
+			},
+			"handled_total": schema.Int64Attribute{
+				MarkdownDescription: "With `handled`, the number of applications the API user has handled whatever the query. Null otherwise.",
+				Computed:            true,
+			},
+			"applications": schema.ListNestedAttribute{
+				MarkdownDescription: "The applications",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Application id",
+							Computed:            true,
+						},
+						"external_id": schema.StringAttribute{
+							MarkdownDescription: "External id of the application, such as `2024/12`",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "State of the application, such as `application.state/submitted`",
+							Computed:            true,
+						},
+						"open": schema.BoolAttribute{
+							MarkdownDescription: "Whether the application is still in progress - a draft, submitted or returned",
+							Computed:            true,
+						},
+						"applicant": schema.StringAttribute{
+							MarkdownDescription: "User id of the applicant",
+							Computed:            true,
+						},
+						"workflow_id": schema.Int64Attribute{
+							MarkdownDescription: "Id of the workflow of the application",
+							Computed:            true,
+						},
+						"catalogue_item_ids": schema.ListAttribute{
+							MarkdownDescription: "Ids of the catalogue items applied for",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+						"resource_ids": schema.ListAttribute{
+							MarkdownDescription: "Ids of the resources applied for",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+						"resids": schema.ListAttribute{
+							MarkdownDescription: "External identifiers of the resources applied for",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"last_activity": schema.StringAttribute{
+							MarkdownDescription: "When the application last changed (RFC 3339)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ApplicationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApplicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var overviews []remsclient.ApplicationOverview
+
+	if data.Handled.ValueBool() {
+		handledReq := d.client.ApplicationsAPI.ApiApplicationsHandledGet(context.Background())
+
+		if !data.Query.IsNull() {
+			handledReq = handledReq.Query(data.Query.ValueString())
+		}
+
+		handled, handledResponse, handledErr := handledReq.Execute()
+
+		if handledErr != nil {
+			resp.Diagnostics.AddError(
+				"Failure to read applications",
+				fmt.Sprintf("Could not read handled applications: %s %v", handledErr.Error(), handledResponse),
+			)
+			return
+		}
+
+		count, countResponse, countErr := d.client.ApplicationsAPI.
+			ApiApplicationsHandledCountGet(context.Background()).
+			Execute()
+
+		if countErr != nil {
+			resp.Diagnostics.AddError(
+				"Failure to read applications",
+				fmt.Sprintf("Could not count handled applications: %s %v", countErr.Error(), countResponse),
+			)
+			return
+		}
+
+		overviews = handled
+		data.HandledTotal = types.Int64Value(count)
+	} else {
+		listReq := d.client.ApplicationsAPI.ApiApplicationsGet(context.Background())
+
+		if !data.Query.IsNull() {
+			listReq = listReq.Query(data.Query.ValueString())
+		}
+
+		list, listResponse, listErr := listReq.Execute()
+
+		if listErr != nil {
+			resp.Diagnostics.AddError(
+				"Failure to read applications",
+				fmt.Sprintf("Could not read applications: %s %v", listErr.Error(), listResponse),
+			)
+			return
+		}
+
+		overviews = list
+		data.HandledTotal = types.Int64Null()
+	}
+
+	data.Total = types.Int64Value(int64(len(overviews)))
+
+	data.Applications = make([]ApplicationDataSourceModel, 0, len(overviews))
+
+	for _, a := range overviews {
+		application := ApplicationDataSourceModel{
+			Id:               types.Int64Value(a.ApplicationId),
+			ExternalId:       types.StringValue(a.ApplicationExternalId),
+			State:            types.StringValue(a.ApplicationState),
+			Open:             types.BoolValue(applications.IsOpen(a.ApplicationState)),
+			Applicant:        types.StringValue(a.ApplicationApplicant.Userid),
+			WorkflowId:       types.Int64Value(a.ApplicationWorkflow.WorkflowId),
+			CatalogueItemIds: make([]types.Int64, 0, len(a.ApplicationResources)),
+			ResourceIds:      make([]types.Int64, 0, len(a.ApplicationResources)),
+			Resids:           make([]types.String, 0, len(a.ApplicationResources)),
+			LastActivity:     types.StringValue(a.ApplicationLastActivity.Format(time.RFC3339)),
+		}
+
+		for _, r := range a.ApplicationResources {
+			application.CatalogueItemIds = append(application.CatalogueItemIds, types.Int64Value(r.CatalogueItemId))
+			application.ResourceIds = append(application.ResourceIds, types.Int64Value(r.ResourceId))
+			application.Resids = append(application.Resids, types.StringValue(r.ResourceExtId))
+		}
+
+		data.Applications = append(data.Applications, application)
+	}
+
+	tflog.Trace(ctx, "read applications")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient_ext"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationsExportDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ApplicationsExportDataSource{}
+
+func NewApplicationsExportDataSource() datasource.DataSource {
+	return &ApplicationsExportDataSource{}
+}
+
+// ApplicationsExportDataSource defines the data source implementation.
+type ApplicationsExportDataSource struct {
+	client *remsclient.APIClient
+}
+
+// ApplicationsExportDataSourceModel describes the data source data model.
+type ApplicationsExportDataSourceModel struct {
+	FormId    types.Int64  `tfsdk:"form_id"`
+	Separator types.String `tfsdk:"separator"`
+	Csv       types.String `tfsdk:"csv"`
+	Header    types.List   `tfsdk:"header"`
+	Rows      types.List   `tfsdk:"rows"`
+}
+
+func (d *ApplicationsExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications_export"
+}
+
+func (d *ApplicationsExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The submitted applications of a form, exported as CSV by REMS",
+
+		Attributes: map[string]schema.Attribute{
+			"form_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the form to export the applications of",
+				Required:            true,
+			},
+			"separator": schema.StringAttribute{
+				MarkdownDescription: "Field separator the REMS instance is configured to export with, a single character. Defaults to `,`.",
+				Optional:            true,
+			},
+			"csv": schema.StringAttribute{
+				MarkdownDescription: "The CSV as exported by REMS",
+				Computed:            true,
+			},
+			"header": schema.ListAttribute{
+				MarkdownDescription: "Column names of the CSV",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"rows": schema.ListAttribute{
+				MarkdownDescription: "Rows of the CSV after the header, one per application, each a map of column name to value",
+				ElementType:         types.MapType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ApplicationsExportDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var separator types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("separator"), &separator)...)
+
+	resp.Diagnostics.Append(validateCsvSeparator(separator)...)
+}
+
+func (d *ApplicationsExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApplicationsExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationsExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	separator := csvDefaultSeparator
+	if !data.Separator.IsNull() {
+		separator = data.Separator.ValueString()
+	}
+
+	export, exportResponse, exportErr := remsclient_ext.ExportApplications(context.Background(), d.client, data.FormId.ValueInt64())
+
+	if exportErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to export applications",
+			fmt.Sprintf("Could not export the applications of form %d: %s %v", data.FormId.ValueInt64(), exportErr.Error(), exportResponse),
+		)
+		return
+	}
+
+	if err := checkCsvContentType(exportResponse.Header.Get("Content-Type")); err != nil {
+		resp.Diagnostics.AddError(
+			"Failure to export applications",
+			fmt.Sprintf("Could not export the applications of form %d: %s", data.FormId.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	header, rows, err := parseCsv(export, separator)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failure to export applications",
+			fmt.Sprintf("Could not parse the applications CSV: %s", err.Error()),
+		)
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	data.Csv = types.StringValue(export)
+
+	data.Header, diags = types.ListValueFrom(ctx, types.StringType, header)
+	resp.Diagnostics.Append(diags...)
+
+	data.Rows, diags = types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, rows)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "exported applications")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
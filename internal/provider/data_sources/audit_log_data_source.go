@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuditLogDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &AuditLogDataSource{}
+
+func NewAuditLogDataSource() datasource.DataSource {
+	return &AuditLogDataSource{}
+}
+
+// AuditLogDataSource defines the data source implementation.
+type AuditLogDataSource struct {
+	client *remsclient.APIClient
+}
+
+// AuditLogDataSourceModel describes the data source data model.
+type AuditLogDataSourceModel struct {
+	UserId        types.String                   `tfsdk:"user_id"`
+	ApplicationId types.Int64                    `tfsdk:"application_id"`
+	After         types.String                   `tfsdk:"after"`
+	Before        types.String                   `tfsdk:"before"`
+	Entries       []AuditLogEntryDataSourceModel `tfsdk:"entries"`
+}
+
+type AuditLogEntryDataSourceModel struct {
+	Time   types.String `tfsdk:"time"`
+	UserId types.String `tfsdk:"user_id"`
+	ApiKey types.String `tfsdk:"api_key"`
+	Method types.String `tfsdk:"method"`
+	Path   types.String `tfsdk:"path"`
+	Status types.String `tfsdk:"status"`
+}
+
+func (d *AuditLogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_log"
+}
+
+func (d *AuditLogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Entries of the REMS audit log - the API calls made - optionally of a single user, application or time window",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Only return the calls made by this user",
+				Optional:            true,
+			},
+			"application_id": schema.Int64Attribute{
+				MarkdownDescription: "Only return the calls about this application",
+				Optional:            true,
+			},
+			"after": schema.StringAttribute{
+				MarkdownDescription: "Only return the calls made after this time (RFC 3339)",
+				Optional:            true,
+			},
+			"before": schema.StringAttribute{
+				MarkdownDescription: "Only return the calls made before this time (RFC 3339)",
+				Optional:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The audit log entries",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"time": schema.StringAttribute{
+							MarkdownDescription: "When the call was made (RFC 3339)",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "User the call was made as, null for anonymous calls",
+							Computed:            true,
+						},
+						"api_key": schema.StringAttribute{
+							MarkdownDescription: "API key the call was made with, if any",
+							Computed:            true,
+						},
+						"method": schema.StringAttribute{
+							MarkdownDescription: "HTTP method of the call",
+							Computed:            true,
+						},
+						"path": schema.StringAttribute{
+							MarkdownDescription: "Path of the call, such as `/api/forms/create`",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "HTTP status of the response",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AuditLogDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	for _, name := range []string{"after", "before"} {
+		var value types.String
+
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if _, err := time.Parse(time.RFC3339, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid time",
+				fmt.Sprintf("The time must be in RFC 3339 format, such as 2024-01-31T12:00:00Z: %s", err.Error()),
+			)
+		}
+	}
+}
+
+func (d *AuditLogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AuditLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuditLogDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auditReq := d.client.AuditLogAPI.ApiAuditLogGet(context.Background())
+
+	if !data.UserId.IsNull() {
+		auditReq = auditReq.Userid(data.UserId.ValueString())
+	}
+
+	if !data.ApplicationId.IsNull() {
+		auditReq = auditReq.ApplicationId(strconv.FormatInt(data.ApplicationId.ValueInt64(), 10))
+	}
+
+	// the times were checked by ValidateConfig
+	if !data.After.IsNull() {
+		after, _ := time.Parse(time.RFC3339, data.After.ValueString())
+		auditReq = auditReq.After(after)
+	}
+
+	if !data.Before.IsNull() {
+		before, _ := time.Parse(time.RFC3339, data.Before.ValueString())
+		auditReq = auditReq.Before(before)
+	}
+
+	entries, entriesResponse, entriesErr := auditReq.Execute()
+
+	if entriesErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read audit log",
+			fmt.Sprintf("Could not read audit log: %s %v", entriesErr.Error(), entriesResponse),
+		)
+		return
+	}
+
+	data.Entries = make([]AuditLogEntryDataSourceModel, 0, len(entries))
+
+	for _, e := range entries {
+		data.Entries = append(data.Entries, AuditLogEntryDataSourceModel{
+			Time:   types.StringValue(e.Time.Format(time.RFC3339)),
+			UserId: types.StringPointerValue(e.Userid.Get()),
+			ApiKey: types.StringPointerValue(e.Apikey.Get()),
+			Method: types.StringValue(e.Method),
+			Path:   types.StringValue(e.Path),
+			Status: types.StringValue(e.Status),
+		})
+	}
+
+	tflog.Trace(ctx, "read audit log")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
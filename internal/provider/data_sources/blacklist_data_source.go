@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BlacklistDataSource{}
+
+func NewBlacklistDataSource() datasource.DataSource {
+	return &BlacklistDataSource{}
+}
+
+// BlacklistDataSource defines the data source implementation.
+type BlacklistDataSource struct {
+	client *remsclient.APIClient
+}
+
+// BlacklistDataSourceModel describes the data source data model.
+type BlacklistDataSourceModel struct {
+	UserId        types.String                    `tfsdk:"user_id"`
+	ResourceResid types.String                    `tfsdk:"resource_resid"`
+	Entries       []BlacklistEntryDataSourceModel `tfsdk:"entries"`
+}
+
+type BlacklistEntryDataSourceModel struct {
+	UserId        types.String `tfsdk:"user_id"`
+	ResourceResid types.String `tfsdk:"resource_resid"`
+	Comment       types.String `tfsdk:"comment"`
+	AddedBy       types.String `tfsdk:"added_by"`
+	AddedAt       types.String `tfsdk:"added_at"`
+}
+
+func (d *BlacklistDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blacklist"
+}
+
+func (d *BlacklistDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Blacklist entries, optionally of a single user or resource",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Only return the entries of this user",
+				Optional:            true,
+			},
+			"resource_resid": schema.StringAttribute{
+				MarkdownDescription: "Only return the entries for the resource with this external identifier",
+				Optional:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The blacklist entries",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "User id of the blacklisted user",
+							Computed:            true,
+						},
+						"resource_resid": schema.StringAttribute{
+							MarkdownDescription: "External identifier of the resource",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Reason for the blacklisting",
+							Computed:            true,
+						},
+						"added_by": schema.StringAttribute{
+							MarkdownDescription: "User id of whoever added the entry",
+							Computed:            true,
+						},
+						"added_at": schema.StringAttribute{
+							MarkdownDescription: "When the entry was added (RFC 3339)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BlacklistDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BlacklistDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BlacklistDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blacklistRequest := d.client.BlacklistAPI.ApiBlacklistGet(context.Background())
+
+	if !data.UserId.IsNull() {
+		blacklistRequest = blacklistRequest.User(data.UserId.ValueString())
+	}
+
+	if !data.ResourceResid.IsNull() {
+		blacklistRequest = blacklistRequest.Resource(data.ResourceResid.ValueString())
+	}
+
+	entries, entriesResponse, entriesErr := blacklistRequest.Execute()
+
+	if entriesErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read blacklist",
+			fmt.Sprintf("Could not read blacklist: %s %v", entriesErr.Error(), entriesResponse),
+		)
+		return
+	}
+
+	data.Entries = make([]BlacklistEntryDataSourceModel, 0, len(entries))
+
+	for _, e := range entries {
+		data.Entries = append(data.Entries, BlacklistEntryDataSourceModel{
+			UserId:        types.StringValue(e.BlacklistUser.Userid),
+			ResourceResid: types.StringValue(e.BlacklistResource.ResourceExtId),
+			Comment:       types.StringValue(e.BlacklistComment),
+			AddedBy:       types.StringValue(e.BlacklistAddedBy.Userid),
+			AddedAt:       types.StringValue(e.BlacklistAddedAt.Format(time.RFC3339)),
+		})
+	}
+
+	tflog.Trace(ctx, "read blacklist")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// CatalogueItemDataSourceModel is a catalogue item as applicants see it.
+type CatalogueItemDataSourceModel struct {
+	Id              types.Int64  `tfsdk:"id"`
+	OrganizationId  types.String `tfsdk:"organization_id"`
+	ResourceId      types.Int64  `tfsdk:"resource_id"`
+	Resid           types.String `tfsdk:"resid"`
+	WorkflowId      types.Int64  `tfsdk:"workflow_id"`
+	FormId          types.Int64  `tfsdk:"form_id"`
+	Localizations   types.Map    `tfsdk:"localizations"`
+	CategoryIds     types.List   `tfsdk:"category_ids"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	Expired         types.Bool   `tfsdk:"expired"`
+	WorkflowEnabled types.Bool   `tfsdk:"workflow_enabled"`
+	FormEnabled     types.Bool   `tfsdk:"form_enabled"`
+}
+
+type CatalogueItemLocalizationDataSourceModel struct {
+	Title   types.String `tfsdk:"title"`
+	Infourl types.String `tfsdk:"infourl"`
+}
+
+var catalogueItemLocalizationType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"title":   types.StringType,
+	"infourl": types.StringType,
+}}
+
+// catalogueItemAttributes is the schema of CatalogueItemDataSourceModel.
+var catalogueItemAttributes = map[string]schema.Attribute{
+	"id": schema.Int64Attribute{
+		MarkdownDescription: "Catalogue item id",
+		Computed:            true,
+	},
+	"organization_id": schema.StringAttribute{
+		MarkdownDescription: "Organization that owns the catalogue item",
+		Computed:            true,
+	},
+	"resource_id": schema.Int64Attribute{
+		MarkdownDescription: "Id of the resource of the catalogue item",
+		Computed:            true,
+	},
+	"resid": schema.StringAttribute{
+		MarkdownDescription: "External identifier of the resource",
+		Computed:            true,
+	},
+	"workflow_id": schema.Int64Attribute{
+		MarkdownDescription: "Id of the workflow of the catalogue item",
+		Computed:            true,
+	},
+	"form_id": schema.Int64Attribute{
+		MarkdownDescription: "Id of the form of the catalogue item, if it has one",
+		Computed:            true,
+	},
+	"localizations": schema.MapNestedAttribute{
+		MarkdownDescription: "Title and info URL of the catalogue item, keyed by language",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"title": schema.StringAttribute{
+					MarkdownDescription: "Title",
+					Computed:            true,
+				},
+				"infourl": schema.StringAttribute{
+					MarkdownDescription: "Info URL",
+					Computed:            true,
+				},
+			},
+		},
+	},
+	"category_ids": schema.ListAttribute{
+		MarkdownDescription: "Ids of the categories of the catalogue item",
+		ElementType:         types.Int64Type,
+		Computed:            true,
+	},
+	"enabled": schema.BoolAttribute{
+		MarkdownDescription: "Whether the catalogue item is enabled",
+		Computed:            true,
+	},
+	"expired": schema.BoolAttribute{
+		MarkdownDescription: "Whether the catalogue item has expired",
+		Computed:            true,
+	},
+	"workflow_enabled": schema.BoolAttribute{
+		MarkdownDescription: "Whether the workflow of the catalogue item is enabled and not archived",
+		Computed:            true,
+	},
+	"form_enabled": schema.BoolAttribute{
+		MarkdownDescription: "Whether the form of the catalogue item is enabled and not archived, null if the item has no form",
+		Computed:            true,
+	},
+}
+
+// catalogueStatus is whether each form and workflow can be used, as the catalogue
+// itself only names them.
+type catalogueStatus struct {
+	forms     map[int64]bool
+	workflows map[int64]bool
+}
+
+// newCatalogueStatus reads the forms and workflows of REMS, disabled and archived
+// ones included.
+func newCatalogueStatus(client *remsclient.APIClient) (*catalogueStatus, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	forms, formsResponse, formsErr := client.FormsAPI.
+		ApiFormsGet(context.Background()).
+		Disabled(true).
+		Archived(true).
+		Execute()
+
+	if formsErr != nil {
+		diags.AddError(
+			"Failure to read forms",
+			fmt.Sprintf("Could not read forms: %s %v", formsErr.Error(), formsResponse),
+		)
+		return nil, diags
+	}
+
+	workflows, workflowsResponse, workflowsErr := client.WorkflowsAPI.
+		ApiWorkflowsGet(context.Background()).
+		Disabled(true).
+		Archived(true).
+		Execute()
+
+	if workflowsErr != nil {
+		diags.AddError(
+			"Failure to read workflows",
+			fmt.Sprintf("Could not read workflows: %s %v", workflowsErr.Error(), workflowsResponse),
+		)
+		return nil, diags
+	}
+
+	status := &catalogueStatus{forms: map[int64]bool{}, workflows: map[int64]bool{}}
+
+	for _, f := range forms {
+		status.forms[f.FormId] = f.Enabled && !f.Archived
+	}
+
+	for _, w := range workflows {
+		status.workflows[w.Id] = w.Enabled && !w.Archived
+	}
+
+	return status, diags
+}
+
+// catalogueItem converts a catalogue item as returned by REMS.
+func (s *catalogueStatus) catalogueItem(ctx context.Context, item remsclient.CatalogueItem) (CatalogueItemDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data := CatalogueItemDataSourceModel{
+		Id:              types.Int64Value(item.Id),
+		OrganizationId:  types.StringValue(item.Organization.OrganizationId),
+		ResourceId:      types.Int64Value(item.ResourceId),
+		Resid:           types.StringValue(item.Resid),
+		WorkflowId:      types.Int64Value(item.Wfid),
+		FormId:          types.Int64PointerValue(item.Formid.Get()),
+		Enabled:         types.BoolValue(item.Enabled),
+		Expired:         types.BoolValue(item.Expired),
+		WorkflowEnabled: types.BoolValue(s.workflows[item.Wfid]),
+		FormEnabled:     types.BoolNull(),
+	}
+
+	if formId := item.Formid.Get(); formId != nil {
+		data.FormEnabled = types.BoolValue(s.forms[*formId])
+	}
+
+	localizations := map[string]CatalogueItemLocalizationDataSourceModel{}
+	for lang, l := range item.Localizations {
+		localizations[lang] = CatalogueItemLocalizationDataSourceModel{
+			Title:   types.StringValue(l.Title),
+			Infourl: types.StringPointerValue(l.Infourl.Get()),
+		}
+	}
+
+	data.Localizations, d = types.MapValueFrom(ctx, catalogueItemLocalizationType, localizations)
+	diags.Append(d...)
+
+	categoryIds := make([]int64, 0, len(item.Categories))
+	for _, c := range item.Categories {
+		categoryIds = append(categoryIds, c.CategoryId)
+	}
+
+	data.CategoryIds, d = types.ListValueFrom(ctx, types.Int64Type, categoryIds)
+	diags.Append(d...)
+
+	return data, diags
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CatalogueDataSource{}
+
+func NewCatalogueDataSource() datasource.DataSource {
+	return &CatalogueDataSource{}
+}
+
+// CatalogueDataSource defines the data source implementation.
+type CatalogueDataSource struct {
+	client *remsclient.APIClient
+}
+
+// CatalogueDataSourceModel describes the data source data model.
+type CatalogueDataSourceModel struct {
+	Items []CatalogueItemDataSourceModel `tfsdk:"items"`
+}
+
+func (d *CatalogueDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalogue"
+}
+
+func (d *CatalogueDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The catalogue items applicants can apply for, along with whether their forms and workflows are usable",
+
+		Attributes: map[string]schema.Attribute{
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "The catalogue items",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: catalogueItemAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *CatalogueDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CatalogueDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CatalogueDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, itemsResponse, itemsErr := d.client.CatalogueAPI.
+		ApiCatalogueGet(context.Background()).
+		Execute()
+
+	if itemsErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read catalogue",
+			fmt.Sprintf("Could not read catalogue: %s %v", itemsErr.Error(), itemsResponse),
+		)
+		return
+	}
+
+	status, diags := newCatalogueStatus(d.client)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Items = make([]CatalogueItemDataSourceModel, 0, len(items))
+
+	for _, i := range items {
+		item, diags := status.catalogueItem(ctx, i)
+		resp.Diagnostics.Append(diags...)
+
+		data.Items = append(data.Items, item)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read catalogue")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
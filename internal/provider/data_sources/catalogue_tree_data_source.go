@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CatalogueTreeDataSource{}
+
+func NewCatalogueTreeDataSource() datasource.DataSource {
+	return &CatalogueTreeDataSource{}
+}
+
+// CatalogueTreeDataSource defines the data source implementation.
+type CatalogueTreeDataSource struct {
+	client *remsclient.APIClient
+}
+
+// CatalogueTreeDataSourceModel describes the data source data model. Terraform
+// schemas cannot nest to any depth, so the categories of the tree are flattened
+// in depth first order with each pointing at its parent.
+type CatalogueTreeDataSourceModel struct {
+	Categories []CatalogueCategoryDataSourceModel `tfsdk:"categories"`
+	Items      []CatalogueItemDataSourceModel     `tfsdk:"items"`
+	ItemIds    []types.Int64                      `tfsdk:"item_ids"`
+}
+
+type CatalogueCategoryDataSourceModel struct {
+	Id           types.Int64   `tfsdk:"id"`
+	ParentId     types.Int64   `tfsdk:"parent_id"`
+	Depth        types.Int64   `tfsdk:"depth"`
+	Title        types.Map     `tfsdk:"title"`
+	Description  types.Map     `tfsdk:"description"`
+	DisplayOrder types.Int64   `tfsdk:"display_order"`
+	ItemIds      []types.Int64 `tfsdk:"item_ids"`
+}
+
+func (d *CatalogueTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalogue_tree"
+}
+
+func (d *CatalogueTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The catalogue as applicants browse it, by category. Catalogue items not in any category are not part of the tree.",
+
+		Attributes: map[string]schema.Attribute{
+			"categories": schema.ListNestedAttribute{
+				MarkdownDescription: "The categories of the tree, each followed by its children",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Category id",
+							Computed:            true,
+						},
+						"parent_id": schema.Int64Attribute{
+							MarkdownDescription: "Id of the parent category, null for a top level category",
+							Computed:            true,
+						},
+						"depth": schema.Int64Attribute{
+							MarkdownDescription: "Depth of the category in the tree, 0 for a top level category",
+							Computed:            true,
+						},
+						"title": schema.MapAttribute{
+							MarkdownDescription: "Title of the category, keyed by language",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"description": schema.MapAttribute{
+							MarkdownDescription: "Description of the category, keyed by language",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"display_order": schema.Int64Attribute{
+							MarkdownDescription: "Display order of the category among its siblings",
+							Computed:            true,
+						},
+						"item_ids": schema.ListAttribute{
+							MarkdownDescription: "Ids of the catalogue items directly in the category",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "The catalogue items in some category of the tree",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: catalogueItemAttributes,
+				},
+			},
+			"item_ids": schema.ListAttribute{
+				MarkdownDescription: "Ids of the catalogue items in some category of the tree",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CatalogueTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CatalogueTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CatalogueTreeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tree, treeResponse, treeErr := d.client.CatalogueAPI.
+		ApiCatalogueTreeGet(context.Background()).
+		Execute()
+
+	if treeErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read catalogue",
+			fmt.Sprintf("Could not read catalogue tree: %s %v", treeErr.Error(), treeResponse),
+		)
+		return
+	}
+
+	status, diags := newCatalogueStatus(d.client)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Categories = []CatalogueCategoryDataSourceModel{}
+	data.Items = []CatalogueItemDataSourceModel{}
+	data.ItemIds = []types.Int64{}
+
+	seen := map[int64]bool{}
+
+	var walk func(category remsclient.CategoryTree, parentId types.Int64, depth int64)
+
+	walk = func(category remsclient.CategoryTree, parentId types.Int64, depth int64) {
+		var d diag.Diagnostics
+
+		model := CatalogueCategoryDataSourceModel{
+			Id:           types.Int64Value(category.CategoryId),
+			ParentId:     parentId,
+			Depth:        types.Int64Value(depth),
+			Description:  types.MapNull(types.StringType),
+			DisplayOrder: types.Int64PointerValue(category.CategoryDisplayOrder),
+			ItemIds:      make([]types.Int64, 0, len(category.CategoryItems)),
+		}
+
+		model.Title, d = types.MapValueFrom(ctx, types.StringType, category.CategoryTitle)
+		resp.Diagnostics.Append(d...)
+
+		if category.CategoryDescription != nil {
+			model.Description, d = types.MapValueFrom(ctx, types.StringType, *category.CategoryDescription)
+			resp.Diagnostics.Append(d...)
+		}
+
+		for _, i := range category.CategoryItems {
+			model.ItemIds = append(model.ItemIds, types.Int64Value(i.Id))
+
+			// an item in several categories is listed once
+			if seen[i.Id] {
+				continue
+			}
+			seen[i.Id] = true
+
+			item, d := status.catalogueItem(ctx, i)
+			resp.Diagnostics.Append(d...)
+
+			data.Items = append(data.Items, item)
+			data.ItemIds = append(data.ItemIds, types.Int64Value(i.Id))
+		}
+
+		data.Categories = append(data.Categories, model)
+
+		for _, child := range category.CategoryChildren {
+			walk(child, model.Id, depth+1)
+		}
+	}
+
+	for _, root := range tree.Roots {
+		walk(root, types.Int64Null(), 0)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read catalogue tree")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigDataSource{}
+
+func NewConfigDataSource() datasource.DataSource {
+	return &ConfigDataSource{}
+}
+
+// ConfigDataSource defines the data source implementation.
+type ConfigDataSource struct {
+	client *remsclient.APIClient
+}
+
+// ConfigDataSourceModel describes the data source data model.
+type ConfigDataSourceModel struct {
+	Full                         types.Bool                       `tfsdk:"full"`
+	Languages                    []types.String                   `tfsdk:"languages"`
+	DefaultLanguage              types.String                     `tfsdk:"default_language"`
+	Authentication               types.String                     `tfsdk:"authentication"`
+	CatalogueIsPublic            types.Bool                       `tfsdk:"catalogue_is_public"`
+	EnableDuo                    types.Bool                       `tfsdk:"enable_duo"`
+	EnableVoting                 types.Bool                       `tfsdk:"enable_voting"`
+	EnableCart                   types.Bool                       `tfsdk:"enable_cart"`
+	EnableCatalogueTree          types.Bool                       `tfsdk:"enable_catalogue_tree"`
+	EnableCatalogueTable         types.Bool                       `tfsdk:"enable_catalogue_table"`
+	EnableDoi                    types.Bool                       `tfsdk:"enable_doi"`
+	EnableAutosave               types.Bool                       `tfsdk:"enable_autosave"`
+	EnableProcessingStates       types.Bool                       `tfsdk:"enable_processing_states"`
+	EntitlementDefaultLengthDays types.Int64                      `tfsdk:"entitlement_default_length_days"`
+	AttachmentMaxSize            types.Int64                      `tfsdk:"attachment_max_size"`
+	ExtraPages                   []ConfigExtraPageDataSourceModel `tfsdk:"extra_pages"`
+	FullJson                     types.String                     `tfsdk:"full_json"`
+}
+
+type ConfigExtraPageDataSourceModel struct {
+	Id         types.String   `tfsdk:"id"`
+	Url        types.String   `tfsdk:"url"`
+	Filename   types.String   `tfsdk:"filename"`
+	Roles      []types.String `tfsdk:"roles"`
+	ShowMenu   types.Bool     `tfsdk:"show_menu"`
+	ShowFooter types.Bool     `tfsdk:"show_footer"`
+	Title      types.Map      `tfsdk:"title"`
+}
+
+func (d *ConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config"
+}
+
+func (d *ConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	toggle := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			MarkdownDescription: description + ", null if the server does not say",
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Settings of the REMS instance, such as its languages and enabled features",
+
+		Attributes: map[string]schema.Attribute{
+			"full": schema.BoolAttribute{
+				MarkdownDescription: "Also read the complete configuration into `full_json`. Only owners may read it.",
+				Optional:            true,
+			},
+			"languages": schema.ListAttribute{
+				MarkdownDescription: "Languages of the instance, such as `[\"en\", \"fi\"]`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"default_language": schema.StringAttribute{
+				MarkdownDescription: "Default language of the instance",
+				Computed:            true,
+			},
+			"authentication": schema.StringAttribute{
+				MarkdownDescription: "Authentication method, such as `oidc`",
+				Computed:            true,
+			},
+			"catalogue_is_public": schema.BoolAttribute{
+				MarkdownDescription: "Whether the catalogue can be browsed without logging in",
+				Computed:            true,
+			},
+			"enable_duo":               toggle("Whether DUO codes are enabled"),
+			"enable_voting":            toggle("Whether reviewers and handlers can vote on applications"),
+			"enable_cart":              toggle("Whether applicants can apply for several catalogue items at once"),
+			"enable_catalogue_tree":    toggle("Whether the catalogue is shown by category"),
+			"enable_catalogue_table":   toggle("Whether the catalogue is shown as a table"),
+			"enable_doi":               toggle("Whether DOIs can be used as resource ids"),
+			"enable_autosave":          toggle("Whether applications are saved automatically"),
+			"enable_processing_states": toggle("Whether workflows can have processing states"),
+			"entitlement_default_length_days": schema.Int64Attribute{
+				MarkdownDescription: "Default length of entitlements in days, null for entitlements that do not end",
+				Computed:            true,
+			},
+			"attachment_max_size": schema.Int64Attribute{
+				MarkdownDescription: "Largest attachment size in bytes, null when there is no limit",
+				Computed:            true,
+			},
+			"extra_pages": schema.ListNestedAttribute{
+				MarkdownDescription: "Extra pages of the instance, such as an about page",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Page id",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "URL of an external page",
+							Computed:            true,
+						},
+						"filename": schema.StringAttribute{
+							MarkdownDescription: "File the page is read from",
+							Computed:            true,
+						},
+						"roles": schema.ListAttribute{
+							MarkdownDescription: "Roles the page is shown to",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"show_menu": schema.BoolAttribute{
+							MarkdownDescription: "Whether the page is in the menu",
+							Computed:            true,
+						},
+						"show_footer": schema.BoolAttribute{
+							MarkdownDescription: "Whether the page is in the footer",
+							Computed:            true,
+						},
+						"title": schema.MapAttribute{
+							MarkdownDescription: "Title of the page, keyed by language",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"full_json": schema.StringAttribute{
+				MarkdownDescription: "The complete configuration as JSON, when `full` is set",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, configResponse, configErr := d.client.ConfigAPI.
+		ApiConfigGet(context.Background()).
+		Execute()
+
+	if configErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read config",
+			fmt.Sprintf("Could not read config: %s %v", configErr.Error(), configResponse),
+		)
+		return
+	}
+
+	data.Languages = make([]types.String, 0, len(config.Languages))
+	for _, l := range config.Languages {
+		data.Languages = append(data.Languages, types.StringValue(l))
+	}
+
+	data.DefaultLanguage = types.StringValue(config.DefaultLanguage)
+	data.Authentication = types.StringValue(config.Authentication)
+	data.CatalogueIsPublic = types.BoolValue(config.CatalogueIsPublic)
+	data.EnableDuo = types.BoolPointerValue(config.EnableDuo)
+	data.EnableVoting = types.BoolPointerValue(config.EnableVoting)
+	data.EnableCart = types.BoolPointerValue(config.EnableCart)
+	data.EnableCatalogueTree = types.BoolPointerValue(config.EnableCatalogueTree)
+	data.EnableCatalogueTable = types.BoolPointerValue(config.EnableCatalogueTable)
+	data.EnableDoi = types.BoolPointerValue(config.EnableDoi)
+	data.EnableAutosave = types.BoolPointerValue(config.EnableAutosave)
+	data.EnableProcessingStates = types.BoolPointerValue(config.EnableProcessingStates)
+	data.EntitlementDefaultLengthDays = types.Int64PointerValue(config.EntitlementDefaultLengthDays.Get())
+	data.AttachmentMaxSize = types.Int64PointerValue(config.AttachmentMaxSize.Get())
+
+	data.ExtraPages = make([]ConfigExtraPageDataSourceModel, 0, len(config.ExtraPages))
+
+	for _, p := range config.ExtraPages {
+		page := ConfigExtraPageDataSourceModel{
+			Id:         types.StringValue(p.Id),
+			Url:        types.StringPointerValue(p.Url),
+			Filename:   types.StringPointerValue(p.Filename),
+			Roles:      make([]types.String, 0, len(p.Roles)),
+			ShowMenu:   types.BoolPointerValue(p.ShowMenu),
+			ShowFooter: types.BoolPointerValue(p.ShowFooter),
+		}
+
+		for _, r := range p.Roles {
+			page.Roles = append(page.Roles, types.StringValue(r))
+		}
+
+		title := map[string]string{}
+		if p.Translations != nil {
+			for lang, t := range *p.Translations {
+				if t.Title != nil {
+					title[lang] = *t.Title
+				}
+			}
+		}
+
+		var diags diag.Diagnostics
+		page.Title, diags = types.MapValueFrom(ctx, types.StringType, title)
+		resp.Diagnostics.Append(diags...)
+
+		data.ExtraPages = append(data.ExtraPages, page)
+	}
+
+	data.FullJson = types.StringNull()
+
+	if data.Full.ValueBool() {
+		full, fullResponse, fullErr := d.client.ConfigAPI.
+			ApiConfigFullGet(context.Background()).
+			Execute()
+
+		if fullErr != nil {
+			resp.Diagnostics.AddError(
+				"Failure to read config",
+				fmt.Sprintf("Could not read full config (only owners may): %s %v", fullErr.Error(), fullResponse),
+			)
+			return
+		}
+
+		fullJson, err := json.Marshal(full)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Failure to read config", fmt.Sprintf("Could not encode full config: %s", err.Error()))
+			return
+		}
+
+		data.FullJson = types.StringValue(string(fullJson))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read config")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
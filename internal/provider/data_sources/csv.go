@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"encoding/csv"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// csvDefaultSeparator is the separator REMS uses when not given one.
+const csvDefaultSeparator = ","
+
+// validateCsvSeparator checks a configured separator is a single character that
+// can separate CSV fields.
+func validateCsvSeparator(separator types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if separator.IsNull() || separator.IsUnknown() {
+		return diags
+	}
+
+	if s := separator.ValueString(); utf8.RuneCountInString(s) != 1 || s == "\"" || s == "\n" || s == "\r" {
+		diags.AddAttributeError(
+			path.Root("separator"),
+			"Invalid separator",
+			fmt.Sprintf("The separator must be a single character other than a quote or line break, got %q.", s),
+		)
+	}
+
+	return diags
+}
+
+// checkCsvContentType rejects a response that is not CSV. An unknown or missing
+// form can come back as a JSON or HTML page rather than an error.
+func checkCsvContentType(contentType string) error {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType == "application/json" || mediaType == "text/html" {
+		return fmt.Errorf("expected CSV but got content type %q", contentType)
+	}
+
+	return nil
+}
+
+// parseCsv splits text into its header and its rows keyed by the header, honouring
+// quoted fields with separators, quotes and line breaks in them. A leading byte order
+// mark, which REMS may add for spreadsheets, is dropped.
+func parseCsv(text string, separator string) ([]string, []map[string]string, error) {
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(text, "\ufeff")))
+	reader.Comma, _ = utf8.DecodeRuneInString(separator)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := []string{}
+	rows := []map[string]string{}
+
+	if len(records) == 0 {
+		return header, rows, nil
+	}
+
+	header = records[0]
+
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			} else {
+				row[column] = ""
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return header, rows, nil
+}
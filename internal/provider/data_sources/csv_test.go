@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCsvSeparator(t *testing.T) {
+	tests := map[string]struct {
+		separator types.String
+		valid     bool
+	}{
+		"null":          {separator: types.StringNull(), valid: true},
+		"unknown":       {separator: types.StringUnknown(), valid: true},
+		"comma":         {separator: types.StringValue(","), valid: true},
+		"semicolon":     {separator: types.StringValue(";"), valid: true},
+		"tab":           {separator: types.StringValue("\t"), valid: true},
+		"multibyte":     {separator: types.StringValue("§"), valid: true},
+		"empty":         {separator: types.StringValue(""), valid: false},
+		"two":           {separator: types.StringValue(";;"), valid: false},
+		"quote":         {separator: types.StringValue("\""), valid: false},
+		"newline":       {separator: types.StringValue("\n"), valid: false},
+		"carriage":      {separator: types.StringValue("\r"), valid: false},
+		"two multibyte": {separator: types.StringValue("§§"), valid: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := validateCsvSeparator(test.separator)
+
+			assert.Equal(t, !test.valid, diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestParseCsv(t *testing.T) {
+	tests := map[string]struct {
+		text      string
+		separator string
+		header    []string
+		rows      []map[string]string
+	}{
+		"empty": {
+			text:      "",
+			separator: ",",
+			header:    []string{},
+			rows:      []map[string]string{},
+		},
+		"header only": {
+			text:      "id,state\n",
+			separator: ",",
+			header:    []string{"id", "state"},
+			rows:      []map[string]string{},
+		},
+		"rows": {
+			text:      "id,state\n1,approved\n2,submitted\n",
+			separator: ",",
+			header:    []string{"id", "state"},
+			rows:      []map[string]string{{"id": "1", "state": "approved"}, {"id": "2", "state": "submitted"}},
+		},
+		"byte order mark": {
+			text:      "\ufeffid;state\n1;approved\n",
+			separator: ";",
+			header:    []string{"id", "state"},
+			rows:      []map[string]string{{"id": "1", "state": "approved"}},
+		},
+		"quoted separators, quotes and line breaks": {
+			text:      "id,description\n1,\"a, \"\"b\"\"\nc\"\n",
+			separator: ",",
+			header:    []string{"id", "description"},
+			rows:      []map[string]string{{"id": "1", "description": "a, \"b\"\nc"}},
+		},
+		"short row": {
+			text:      "id,state,comment\n1,approved\n",
+			separator: ",",
+			header:    []string{"id", "state", "comment"},
+			rows:      []map[string]string{{"id": "1", "state": "approved", "comment": ""}},
+		},
+		"other separator leaves commas alone": {
+			text:      "id\tname\n1\tSmith, Jo\n",
+			separator: "\t",
+			header:    []string{"id", "name"},
+			rows:      []map[string]string{{"id": "1", "name": "Smith, Jo"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			header, rows, err := parseCsv(test.text, test.separator)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.header, header)
+			assert.Equal(t, test.rows, rows)
+		})
+	}
+}
+
+func TestParseCsvInvalid(t *testing.T) {
+	_, _, err := parseCsv("id,name\n1,\"unterminated\n", ",")
+
+	assert.Error(t, err)
+}
+
+func TestCheckCsvContentType(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		valid       bool
+	}{
+		"csv":                 {contentType: "text/csv", valid: true},
+		"csv with a charset":  {contentType: "text/csv; charset=utf-8", valid: true},
+		"none":                {contentType: "", valid: true},
+		"octet stream":        {contentType: "application/octet-stream", valid: true},
+		"json":                {contentType: "application/json", valid: false},
+		"json with a charset": {contentType: "application/json; charset=utf-8", valid: false},
+		"html":                {contentType: "text/html", valid: false},
+		"html with a charset": {contentType: "text/html;charset=UTF-8", valid: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkCsvContentType(test.contentType)
+
+			assert.Equal(t, !test.valid, err != nil, "%v", err)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DuoCodesDataSource{}
+
+func NewDuoCodesDataSource() datasource.DataSource {
+	return &DuoCodesDataSource{}
+}
+
+// DuoCodesDataSource defines the data source implementation.
+type DuoCodesDataSource struct {
+	client *remsclient.APIClient
+}
+
+// DuoCodesDataSourceModel describes the data source data model.
+type DuoCodesDataSourceModel struct {
+	Label types.String             `tfsdk:"label"`
+	Codes []DuoCodeDataSourceModel `tfsdk:"codes"`
+}
+
+type DuoCodeDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Shorthand    types.String `tfsdk:"shorthand"`
+	Label        types.Map    `tfsdk:"label"`
+	Description  types.Map    `tfsdk:"description"`
+	Restrictions types.List   `tfsdk:"restrictions"`
+}
+
+func (d *DuoCodesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_duo_codes"
+}
+
+func (d *DuoCodesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data Use Ontology (DUO) codes known to REMS",
+
+		Attributes: map[string]schema.Attribute{
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Only return codes with a label or shorthand containing this text (ignoring case)",
+				Optional:            true,
+			},
+			"codes": schema.ListNestedAttribute{
+				MarkdownDescription: "The DUO codes",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Code id, such as `DUO:0000007`",
+							Computed:            true,
+						},
+						"shorthand": schema.StringAttribute{
+							MarkdownDescription: "Short name of the code, such as `DS`",
+							Computed:            true,
+						},
+						"label": schema.MapAttribute{
+							MarkdownDescription: "Label of the code, keyed by language",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"description": schema.MapAttribute{
+							MarkdownDescription: "Description of the code, keyed by language",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"restrictions": schema.ListAttribute{
+							MarkdownDescription: "Types of the restrictions the code takes, such as `mondo`",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DuoCodesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DuoCodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DuoCodesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	codes, codesResponse, codesErr := d.client.ResourcesAPI.
+		ApiResourcesDuoCodesGet(context.Background()).
+		Execute()
+
+	if codesErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read DUO codes",
+			fmt.Sprintf("Could not read DUO codes: %s %v", codesErr.Error(), codesResponse),
+		)
+		return
+	}
+
+	search := strings.ToLower(data.Label.ValueString())
+	data.Codes = make([]DuoCodeDataSourceModel, 0, len(codes))
+
+	for _, c := range codes {
+		if search != "" && !duoCodeMatches(c, search) {
+			continue
+		}
+
+		restrictions := make([]string, 0, len(c.Restrictions))
+		for _, r := range c.Restrictions {
+			restrictions = append(restrictions, r.Type)
+		}
+
+		code := DuoCodeDataSourceModel{
+			Id:        types.StringValue(c.Id),
+			Shorthand: types.StringPointerValue(c.Shorthand.Get()),
+		}
+
+		var diags diag.Diagnostics
+
+		code.Label, diags = types.MapValueFrom(ctx, types.StringType, c.Label)
+		resp.Diagnostics.Append(diags...)
+
+		code.Description, diags = types.MapValueFrom(ctx, types.StringType, c.Description)
+		resp.Diagnostics.Append(diags...)
+
+		code.Restrictions, diags = types.ListValueFrom(ctx, types.StringType, restrictions)
+		resp.Diagnostics.Append(diags...)
+
+		data.Codes = append(data.Codes, code)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read DUO codes")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// duoCodeMatches is whether search (in lower case) is in the shorthand or a label of the code.
+func duoCodeMatches(code remsclient.DuoCodeFull, search string) bool {
+	if strings.Contains(strings.ToLower(code.GetShorthand()), search) {
+		return true
+	}
+
+	for _, label := range code.Label {
+		if strings.Contains(strings.ToLower(label), search) {
+			return true
+		}
+	}
+
+	return false
+}
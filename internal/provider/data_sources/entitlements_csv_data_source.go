@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EntitlementsCsvDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &EntitlementsCsvDataSource{}
+
+func NewEntitlementsCsvDataSource() datasource.DataSource {
+	return &EntitlementsCsvDataSource{}
+}
+
+// EntitlementsCsvDataSource defines the data source implementation.
+type EntitlementsCsvDataSource struct {
+	client *remsclient.APIClient
+}
+
+// EntitlementsCsvDataSourceModel describes the data source data model.
+type EntitlementsCsvDataSourceModel struct {
+	User      types.String `tfsdk:"user"`
+	Resource  types.String `tfsdk:"resource"`
+	Expired   types.Bool   `tfsdk:"expired"`
+	Separator types.String `tfsdk:"separator"`
+	Csv       types.String `tfsdk:"csv"`
+	Header    types.List   `tfsdk:"header"`
+	Rows      types.List   `tfsdk:"rows"`
+}
+
+func (d *EntitlementsCsvDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlements_csv"
+}
+
+func (d *EntitlementsCsvDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Entitlements exported as CSV by REMS, optionally of a single user or resource",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only export the entitlements of this user id",
+				Optional:            true,
+			},
+			"resource": schema.StringAttribute{
+				MarkdownDescription: "Only export the entitlements to the resource with this external identifier",
+				Optional:            true,
+			},
+			"expired": schema.BoolAttribute{
+				MarkdownDescription: "Include expired entitlements",
+				Optional:            true,
+			},
+			"separator": schema.StringAttribute{
+				MarkdownDescription: "Field separator, a single character. Defaults to `,`.",
+				Optional:            true,
+			},
+			"csv": schema.StringAttribute{
+				MarkdownDescription: "The CSV as exported by REMS",
+				Computed:            true,
+			},
+			"header": schema.ListAttribute{
+				MarkdownDescription: "Column names of the CSV",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"rows": schema.ListAttribute{
+				MarkdownDescription: "Rows of the CSV after the header, each a map of column name to value",
+				ElementType:         types.MapType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *EntitlementsCsvDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var separator types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("separator"), &separator)...)
+
+	resp.Diagnostics.Append(validateCsvSeparator(separator)...)
+}
+
+func (d *EntitlementsCsvDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EntitlementsCsvDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EntitlementsCsvDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	separator := csvDefaultSeparator
+	if !data.Separator.IsNull() {
+		separator = data.Separator.ValueString()
+	}
+
+	exportReq := d.client.EntitlementsAPI.
+		ApiEntitlementsExportCsvGet(context.Background()).
+		Expired(data.Expired.ValueBool()).
+		Separator(separator)
+
+	if !data.User.IsNull() {
+		exportReq = exportReq.User(data.User.ValueString())
+	}
+
+	if !data.Resource.IsNull() {
+		exportReq = exportReq.Resource(data.Resource.ValueString())
+	}
+
+	export, exportResponse, exportErr := exportReq.Execute()
+
+	if exportErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to export entitlements",
+			fmt.Sprintf("Could not export entitlements: %s %v", exportErr.Error(), exportResponse),
+		)
+		return
+	}
+
+	header, rows, err := parseCsv(export, separator)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failure to export entitlements",
+			fmt.Sprintf("Could not parse the entitlements CSV: %s", err.Error()),
+		)
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	data.Csv = types.StringValue(export)
+
+	data.Header, diags = types.ListValueFrom(ctx, types.StringType, header)
+	resp.Diagnostics.Append(diags...)
+
+	data.Rows, diags = types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, rows)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "exported entitlements")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
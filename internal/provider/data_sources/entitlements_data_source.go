@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EntitlementsDataSource{}
+
+func NewEntitlementsDataSource() datasource.DataSource {
+	return &EntitlementsDataSource{}
+}
+
+// EntitlementsDataSource defines the data source implementation.
+type EntitlementsDataSource struct {
+	client *remsclient.APIClient
+}
+
+// EntitlementsDataSourceModel describes the data source data model.
+type EntitlementsDataSourceModel struct {
+	User         types.String                 `tfsdk:"user"`
+	Resource     types.String                 `tfsdk:"resource"`
+	Expired      types.Bool                   `tfsdk:"expired"`
+	Entitlements []EntitlementDataSourceModel `tfsdk:"entitlements"`
+}
+
+type EntitlementDataSourceModel struct {
+	UserId        types.String `tfsdk:"user_id"`
+	UserName      types.String `tfsdk:"user_name"`
+	UserEmail     types.String `tfsdk:"user_email"`
+	Resource      types.String `tfsdk:"resource"`
+	ApplicationId types.Int64  `tfsdk:"application_id"`
+	Start         types.String `tfsdk:"start"`
+	End           types.String `tfsdk:"end"`
+	Mail          types.String `tfsdk:"mail"`
+}
+
+func (d *EntitlementsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlements"
+}
+
+func (d *EntitlementsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Entitlements (access granted by approved applications), optionally of a single user or resource",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only return the entitlements of this user id",
+				Optional:            true,
+			},
+			"resource": schema.StringAttribute{
+				MarkdownDescription: "Only return the entitlements to the resource with this external identifier",
+				Optional:            true,
+			},
+			"expired": schema.BoolAttribute{
+				MarkdownDescription: "Include expired entitlements",
+				Optional:            true,
+			},
+			"entitlements": schema.ListNestedAttribute{
+				MarkdownDescription: "The entitlements",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "User id of the entitled user",
+							Computed:            true,
+						},
+						"user_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the entitled user",
+							Computed:            true,
+						},
+						"user_email": schema.StringAttribute{
+							MarkdownDescription: "Email of the entitled user",
+							Computed:            true,
+						},
+						"resource": schema.StringAttribute{
+							MarkdownDescription: "External identifier of the resource",
+							Computed:            true,
+						},
+						"application_id": schema.Int64Attribute{
+							MarkdownDescription: "Id of the application that granted the entitlement",
+							Computed:            true,
+						},
+						"start": schema.StringAttribute{
+							MarkdownDescription: "When the entitlement started (RFC 3339)",
+							Computed:            true,
+						},
+						"end": schema.StringAttribute{
+							MarkdownDescription: "When the entitlement ends or ended (RFC 3339), null if it does not end",
+							Computed:            true,
+						},
+						"mail": schema.StringAttribute{
+							MarkdownDescription: "Email of the entitled user, as REMS reports it with the entitlement",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EntitlementsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EntitlementsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EntitlementsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entitlementsReq := d.client.EntitlementsAPI.
+		ApiEntitlementsGet(context.Background()).
+		Expired(data.Expired.ValueBool())
+
+	if !data.User.IsNull() {
+		entitlementsReq = entitlementsReq.User(data.User.ValueString())
+	}
+
+	if !data.Resource.IsNull() {
+		entitlementsReq = entitlementsReq.Resource(data.Resource.ValueString())
+	}
+
+	entitlements, entitlementsResponse, entitlementsErr := entitlementsReq.Execute()
+
+	if entitlementsErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read entitlements",
+			fmt.Sprintf("Could not read entitlements: %s %v", entitlementsErr.Error(), entitlementsResponse),
+		)
+		return
+	}
+
+	data.Entitlements = make([]EntitlementDataSourceModel, 0, len(entitlements))
+
+	for _, e := range entitlements {
+		entitlement := EntitlementDataSourceModel{
+			UserId:        types.StringValue(e.User.Userid),
+			UserName:      types.StringPointerValue(e.User.Name.Get()),
+			UserEmail:     types.StringPointerValue(e.User.Email.Get()),
+			Resource:      types.StringValue(e.Resource),
+			ApplicationId: types.Int64Value(e.ApplicationId),
+			Start:         types.StringValue(e.Start.Format(time.RFC3339)),
+			End:           types.StringNull(),
+			Mail:          types.StringPointerValue(e.Mail.Get()),
+		}
+
+		if end := e.End.Get(); end != nil {
+			entitlement.End = types.StringValue(end.Format(time.RFC3339))
+		}
+
+		data.Entitlements = append(data.Entitlements, entitlement)
+	}
+
+	tflog.Trace(ctx, "read entitlements")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
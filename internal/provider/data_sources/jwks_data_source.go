@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JwksDataSource{}
+
+func NewJwksDataSource() datasource.DataSource {
+	return &JwksDataSource{}
+}
+
+// JwksDataSource defines the data source implementation.
+type JwksDataSource struct {
+	client *remsclient.APIClient
+}
+
+// JwksDataSourceModel describes the data source data model.
+type JwksDataSourceModel struct {
+	Json types.String         `tfsdk:"json"`
+	Keys []JwkDataSourceModel `tfsdk:"keys"`
+}
+
+type JwkDataSourceModel struct {
+	Kid types.String `tfsdk:"kid"`
+	Kty types.String `tfsdk:"kty"`
+	Alg types.String `tfsdk:"alg"`
+	Use types.String `tfsdk:"use"`
+	N   types.String `tfsdk:"n"`
+	E   types.String `tfsdk:"e"`
+}
+
+func (d *JwksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwks"
+}
+
+func (d *JwksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The JSON Web Key Set (JWKS) REMS signs GA4GH visas with. REMS only publishes it when its permissions API is enabled.",
+
+		Attributes: map[string]schema.Attribute{
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The key set as a JSON document, ready to hand to services verifying visas",
+				Computed:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The keys of the set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							MarkdownDescription: "Key id, as in the header of the visas signed with the key",
+							Computed:            true,
+						},
+						"kty": schema.StringAttribute{
+							MarkdownDescription: "Key type, such as `RSA`",
+							Computed:            true,
+						},
+						"alg": schema.StringAttribute{
+							MarkdownDescription: "Signing algorithm, such as `RS256`",
+							Computed:            true,
+						},
+						"use": schema.StringAttribute{
+							MarkdownDescription: "Intended use of the key, such as `sig`",
+							Computed:            true,
+						},
+						"n": schema.StringAttribute{
+							MarkdownDescription: "Modulus of an RSA key",
+							Computed:            true,
+						},
+						"e": schema.StringAttribute{
+							MarkdownDescription: "Exponent of an RSA key",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *JwksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *JwksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwksDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwks, jwksResponse, jwksErr := d.client.PermissionsAPI.
+		ApiJwkGet(context.Background()).
+		Execute()
+
+	if jwksErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read JWKS",
+			fmt.Sprintf("Could not read JWKS: %s %v", jwksErr.Error(), jwksResponse),
+		)
+		return
+	}
+
+	jwksJson, err := json.Marshal(jwks)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read JWKS", fmt.Sprintf("Could not encode JWKS: %s", err.Error()))
+		return
+	}
+
+	data.Json = types.StringValue(string(jwksJson))
+	data.Keys = make([]JwkDataSourceModel, 0, len(jwks.Keys))
+
+	for _, k := range jwks.Keys {
+		data.Keys = append(data.Keys, JwkDataSourceModel{
+			Kid: jwkMember(k, "kid"),
+			Kty: jwkMember(k, "kty"),
+			Alg: jwkMember(k, "alg"),
+			Use: jwkMember(k, "use"),
+			N:   jwkMember(k, "n"),
+			E:   jwkMember(k, "e"),
+		})
+	}
+
+	tflog.Trace(ctx, "read JWKS")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// jwkMember is a string member of a key, null if the key does not have it.
+func jwkMember(key map[string]interface{}, name string) types.String {
+	if v, ok := key[name].(string); ok {
+		return types.StringValue(v)
+	}
+
+	return types.StringNull()
+}
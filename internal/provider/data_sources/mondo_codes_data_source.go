@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MondoCodesDataSource{}
+
+func NewMondoCodesDataSource() datasource.DataSource {
+	return &MondoCodesDataSource{}
+}
+
+// MondoCodesDataSource defines the data source implementation.
+type MondoCodesDataSource struct {
+	client *remsclient.APIClient
+}
+
+// MondoCodesDataSourceModel describes the data source data model.
+type MondoCodesDataSourceModel struct {
+	SearchText types.String               `tfsdk:"search_text"`
+	Codes      []MondoCodeDataSourceModel `tfsdk:"codes"`
+}
+
+type MondoCodeDataSourceModel struct {
+	Id    types.String `tfsdk:"id"`
+	Label types.String `tfsdk:"label"`
+}
+
+func (d *MondoCodesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mondo_codes"
+}
+
+func (d *MondoCodesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Monarch Disease Ontology (MONDO) codes known to REMS, as used in `mondo` DUO restrictions",
+
+		Attributes: map[string]schema.Attribute{
+			"search_text": schema.StringAttribute{
+				MarkdownDescription: "Only return codes matching this text, as searched by REMS. Without it every code is returned, which is a lot of codes.",
+				Optional:            true,
+			},
+			"codes": schema.ListNestedAttribute{
+				MarkdownDescription: "The MONDO codes",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Code id, such as `MONDO:0007254`",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "Label of the code",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *MondoCodesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MondoCodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MondoCodesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var codes []remsclient.MondoCodeFull
+	var codesResponse *http.Response
+	var codesErr error
+
+	if data.SearchText.IsNull() {
+		codes, codesResponse, codesErr = d.client.ResourcesAPI.
+			ApiResourcesMondoCodesGet(context.Background()).
+			Execute()
+	} else {
+		codes, codesResponse, codesErr = d.client.ResourcesAPI.
+			ApiResourcesSearchMondoCodesGet(context.Background()).
+			SearchText(data.SearchText.ValueString()).
+			Execute()
+	}
+
+	if codesErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read MONDO codes",
+			fmt.Sprintf("Could not read MONDO codes: %s %v", codesErr.Error(), codesResponse),
+		)
+		return
+	}
+
+	data.Codes = make([]MondoCodeDataSourceModel, 0, len(codes))
+
+	for _, c := range codes {
+		data.Codes = append(data.Codes, MondoCodeDataSourceModel{
+			Id:    types.StringValue(c.Id),
+			Label: types.StringValue(c.Label),
+		})
+	}
+
+	tflog.Trace(ctx, "read MONDO codes")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
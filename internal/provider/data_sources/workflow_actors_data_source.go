@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowActorsDataSource{}
+
+func NewWorkflowActorsDataSource() datasource.DataSource {
+	return &WorkflowActorsDataSource{}
+}
+
+// WorkflowActorsDataSource defines the data source implementation.
+type WorkflowActorsDataSource struct {
+	client *remsclient.APIClient
+}
+
+// WorkflowActorsDataSourceModel describes the data source data model.
+type WorkflowActorsDataSourceModel struct {
+	Userids types.List                     `tfsdk:"userids"`
+	Actors  []WorkflowActorDataSourceModel `tfsdk:"actors"`
+}
+
+type WorkflowActorDataSourceModel struct {
+	Userid types.String `tfsdk:"userid"`
+	Name   types.String `tfsdk:"name"`
+	Email  types.String `tfsdk:"email"`
+}
+
+func (d *WorkflowActorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_actors"
+}
+
+func (d *WorkflowActorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Users that can act as handlers (or reviewers and deciders) of a workflow",
+
+		Attributes: map[string]schema.Attribute{
+			"userids": schema.ListAttribute{
+				MarkdownDescription: "User ids of the actors",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"actors": schema.ListNestedAttribute{
+				MarkdownDescription: "The actors",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"userid": schema.StringAttribute{
+							MarkdownDescription: "User id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the user",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Email address of the user",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkflowActorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WorkflowActorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkflowActorsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actors, actorsResponse, actorsErr := d.client.WorkflowsAPI.
+		ApiWorkflowsActorsGet(context.Background()).
+		Execute()
+
+	if actorsErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read workflow actors",
+			fmt.Sprintf("Could not read workflow actors: %s %v", actorsErr.Error(), actorsResponse),
+		)
+		return
+	}
+
+	userids := make([]string, 0, len(actors))
+	data.Actors = make([]WorkflowActorDataSourceModel, 0, len(actors))
+
+	for _, a := range actors {
+		userids = append(userids, a.Userid)
+		data.Actors = append(data.Actors, WorkflowActorDataSourceModel{
+			Userid: types.StringValue(a.Userid),
+			Name:   types.StringPointerValue(a.Name.Get()),
+			Email:  types.StringPointerValue(a.Email.Get()),
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.Userids, diags = types.ListValueFrom(ctx, types.StringType, userids)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read workflow actors")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeral_resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ApiUserEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ApiUserEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &ApiUserEphemeralResource{}
+
+func NewApiUserEphemeralResource() ephemeral.EphemeralResource {
+	return &ApiUserEphemeralResource{}
+}
+
+// ApiUserEphemeralResource sets up a REMS user for the length of a Terraform run.
+type ApiUserEphemeralResource struct {
+	client *remsclient.APIClient
+}
+
+// ApiUserEphemeralResourceModel describes the ephemeral resource data model.
+type ApiUserEphemeralResourceModel struct {
+	Userid        types.String `tfsdk:"userid"`
+	Name          types.String `tfsdk:"name"`
+	Email         types.String `tfsdk:"email"`
+	Organizations types.List   `tfsdk:"organizations"`
+	ApiKey        types.String `tfsdk:"api_key"`
+}
+
+// apiUserPrivate is what Close needs to know of the user Open set up.
+type apiUserPrivate struct {
+	Userid string  `json:"userid"`
+	Name   *string `json:"name"`
+	Email  *string `json:"email"`
+}
+
+const apiUserPrivateKey = "user"
+
+func (r *ApiUserEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_user"
+}
+
+func (r *ApiUserEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A REMS user set up for the length of a Terraform run, along with the headers to act as it. " +
+			"The user is created by the first run and edited to match the configuration by later ones. REMS has no API " +
+			"for creating API keys, so `api_key` must be given - use a key that REMS restricts to non-admin paths, as " +
+			"the key of the provider is never handed out.\n\n" +
+			"~> **Known limitation:** REMS cannot delete users, so the user stays in REMS between runs. Once a run " +
+			"ends the user is only stripped of its organizations.",
+
+		Attributes: map[string]schema.Attribute{
+			"userid": schema.StringAttribute{
+				MarkdownDescription: "Id of the user, to send as the `x-rems-user-id` header. Keep it to a user of its own, " +
+					"not managed by a `remscontent_user`, as every run overwrites its name, email and organizations",
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the user",
+				Optional:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email of the user",
+				Optional:            true,
+			},
+			"organizations": schema.ListAttribute{
+				MarkdownDescription: "Ids of the organizations the user belongs to while the run lasts",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key to send as the `x-rems-api-key` header, handed back as given",
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *ApiUserEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ApiUserEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ApiUserEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationIds := []string{}
+	resp.Diagnostics.Append(data.Organizations.ElementsAs(ctx, &organizationIds, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, usersResponse, usersErr := r.client.UsersAPI.ApiUsersActiveGet(context.Background()).Execute()
+
+	if usersErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to set up user",
+			fmt.Sprintf("Could not check for an existing user %s: %s %v", data.Userid.ValueString(), usersErr.Error(), usersResponse),
+		)
+		return
+	}
+
+	exists := false
+	for _, u := range users {
+		if u.Userid == data.Userid.ValueString() {
+			exists = true
+			break
+		}
+	}
+
+	organizations := []remsclient.OrganizationId{}
+	for _, o := range organizationIds {
+		organizations = append(organizations, *remsclient.NewOrganizationId(o))
+	}
+
+	// REMS never deletes users, so a user left by an earlier run is taken over
+	var result *remsclient.SuccessResponse
+	var response *http.Response
+	var err error
+
+	if exists {
+		userConfig := remsclient.NewEditUserCommand(
+			data.Userid.ValueString(),
+			*remsclient.NewNullableString(data.Name.ValueStringPointer()),
+			*remsclient.NewNullableString(data.Email.ValueStringPointer()),
+		)
+		userConfig.Organizations = organizations
+
+		result, response, err = r.client.UsersAPI.
+			ApiUsersEditPut(context.Background()).
+			EditUserCommand(*userConfig).
+			Execute()
+	} else {
+		userConfig := remsclient.NewCreateUserCommand(
+			data.Userid.ValueString(),
+			*remsclient.NewNullableString(data.Name.ValueStringPointer()),
+			*remsclient.NewNullableString(data.Email.ValueStringPointer()),
+		)
+		userConfig.Organizations = organizations
+
+		result, response, err = r.client.UsersAPI.
+			ApiUsersCreatePost(context.Background()).
+			CreateUserCommand(*userConfig).
+			Execute()
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failure to set up user",
+			fmt.Sprintf("Could not set up user %s: %s %v", data.Userid.ValueString(), err.Error(), response),
+		)
+		return
+	}
+
+	if !result.Success {
+		resp.Diagnostics.AddError(
+			"Failure to set up user",
+			fmt.Sprintf("Could not set up user %s: %v", data.Userid.ValueString(), result.GetErrors()),
+		)
+		return
+	}
+
+	private, err := json.Marshal(apiUserPrivate{
+		Userid: data.Userid.ValueString(),
+		Name:   data.Name.ValueStringPointer(),
+		Email:  data.Email.ValueStringPointer(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to set up user", fmt.Sprintf("Could not record user %s: %s", data.Userid.ValueString(), err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiUserPrivateKey, private)...)
+
+	tflog.Trace(ctx, "set up an ephemeral user", map[string]interface{}{"userid": data.Userid.ValueString()})
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *ApiUserEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateBytes, diags := req.Private.GetKey(ctx, apiUserPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || privateBytes == nil {
+		return
+	}
+
+	var private apiUserPrivate
+
+	if err := json.Unmarshal(privateBytes, &private); err != nil {
+		resp.Diagnostics.AddError("Failure to clean up user", fmt.Sprintf("Could not read the user set up: %s", err.Error()))
+		return
+	}
+
+	// REMS has no way to delete a user - the closest is taking away its organizations
+	userConfig := remsclient.NewEditUserCommand(
+		private.Userid,
+		*remsclient.NewNullableString(private.Name),
+		*remsclient.NewNullableString(private.Email),
+	)
+	userConfig.Organizations = []remsclient.OrganizationId{}
+
+	editResult, editResponse, editErr := r.client.UsersAPI.
+		ApiUsersEditPut(context.Background()).
+		EditUserCommand(*userConfig).
+		Execute()
+
+	if editErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to clean up user",
+			fmt.Sprintf("Could not edit user %s: %s %v", private.Userid, editErr.Error(), editResponse),
+		)
+		return
+	}
+
+	if !editResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to clean up user",
+			fmt.Sprintf("Could not edit user %s: %v", private.Userid, editResult.GetErrors()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "cleaned up an ephemeral user", map[string]interface{}{"userid": private.Userid})
+}
@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeral_resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &Ga4ghVisasEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &Ga4ghVisasEphemeralResource{}
+
+func NewGa4ghVisasEphemeralResource() ephemeral.EphemeralResource {
+	return &Ga4ghVisasEphemeralResource{}
+}
+
+// Ga4ghVisasEphemeralResource fetches the GA4GH visas of a user, verified against
+// the keys REMS publishes.
+type Ga4ghVisasEphemeralResource struct {
+	client *remsclient.APIClient
+}
+
+// Ga4ghVisasEphemeralResourceModel describes the ephemeral resource data model.
+type Ga4ghVisasEphemeralResourceModel struct {
+	User    types.String                      `tfsdk:"user"`
+	Expired types.Bool                        `tfsdk:"expired"`
+	Visas   []Ga4ghVisaEphemeralResourceModel `tfsdk:"visas"`
+}
+
+type Ga4ghVisaEphemeralResourceModel struct {
+	Jwt        types.String `tfsdk:"jwt"`
+	KeyId      types.String `tfsdk:"key_id"`
+	Issuer     types.String `tfsdk:"issuer"`
+	Subject    types.String `tfsdk:"subject"`
+	IssuedAt   types.String `tfsdk:"issued_at"`
+	ExpiresAt  types.String `tfsdk:"expires_at"`
+	Type       types.String `tfsdk:"type"`
+	Value      types.String `tfsdk:"value"`
+	Source     types.String `tfsdk:"source"`
+	By         types.String `tfsdk:"by"`
+	Asserted   types.String `tfsdk:"asserted"`
+	ClaimsJson types.String `tfsdk:"claims_json"`
+}
+
+func (r *Ga4ghVisasEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ga4gh_visas"
+}
+
+func (r *Ga4ghVisasEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The GA4GH visas REMS issues to a user for their entitlements. Every visa is verified against the " +
+			"keys of the `remscontent_jwks` data source, must be issued by the `endpoint` of the provider and must be valid " +
+			"now (allowing a minute of clock difference), and opening fails if any does not verify.",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "User id to fetch the visas of",
+				Required:            true,
+			},
+			"expired": schema.BoolAttribute{
+				MarkdownDescription: "Include visas for expired entitlements. Their visas may themselves have expired, so the expiry of visas is not checked.",
+				Optional:            true,
+			},
+			"visas": schema.ListNestedAttribute{
+				MarkdownDescription: "The verified visas",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"jwt": schema.StringAttribute{
+							MarkdownDescription: "The signed visa",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"key_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the key the visa is signed with",
+							Computed:            true,
+						},
+						"issuer": schema.StringAttribute{
+							MarkdownDescription: "Issuer (`iss`) of the visa",
+							Computed:            true,
+						},
+						"subject": schema.StringAttribute{
+							MarkdownDescription: "Subject (`sub`) of the visa",
+							Computed:            true,
+						},
+						"issued_at": schema.StringAttribute{
+							MarkdownDescription: "When the visa was issued (RFC 3339)",
+							Computed:            true,
+						},
+						"expires_at": schema.StringAttribute{
+							MarkdownDescription: "When the visa expires (RFC 3339)",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Visa type, such as `ControlledAccessGrants`",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Visa value, the external identifier of the resource for access grants",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "Source of the visa",
+							Computed:            true,
+						},
+						"by": schema.StringAttribute{
+							MarkdownDescription: "Who the visa was asserted by, such as `dac`",
+							Computed:            true,
+						},
+						"asserted": schema.StringAttribute{
+							MarkdownDescription: "When the visa was asserted (RFC 3339)",
+							Computed:            true,
+						},
+						"claims_json": schema.StringAttribute{
+							MarkdownDescription: "All the claims of the visa as JSON",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Ga4ghVisasEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *Ga4ghVisasEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data Ga4ghVisasEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwks, jwksResponse, jwksErr := r.client.PermissionsAPI.
+		ApiJwkGet(context.Background()).
+		Execute()
+
+	if jwksErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read JWKS",
+			fmt.Sprintf("Could not read JWKS: %s %v", jwksErr.Error(), jwksResponse),
+		)
+		return
+	}
+
+	permissions, permissionsResponse, permissionsErr := r.client.PermissionsAPI.
+		ApiPermissionsUserGet(context.Background(), data.User.ValueString()).
+		Expired(data.Expired.ValueBool()).
+		Execute()
+
+	if permissionsErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read visas",
+			fmt.Sprintf("Could not read visas of %s: %s %v", data.User.ValueString(), permissionsErr.Error(), permissionsResponse),
+		)
+		return
+	}
+
+	cfg := r.client.GetConfig()
+
+	expected := jwtExpectations{
+		Issuer:       fmt.Sprintf("%s://%s", cfg.Scheme, cfg.Host),
+		Now:          time.Now(),
+		AllowExpired: data.Expired.ValueBool(),
+	}
+
+	data.Visas = make([]Ga4ghVisaEphemeralResourceModel, 0, len(permissions.Ga4ghPassportV1))
+
+	for i, token := range permissions.Ga4ghPassportV1 {
+		header, claims, err := verifyJwt(token, jwks.Keys, expected)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failure to verify visa",
+				fmt.Sprintf("Could not verify visa %d of %s: %s", i, data.User.ValueString(), err.Error()),
+			)
+			continue
+		}
+
+		claimsJson, err := json.Marshal(claims)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Failure to verify visa", fmt.Sprintf("Could not encode the claims of visa %d: %s", i, err.Error()))
+			continue
+		}
+
+		visa, _ := claims["ga4gh_visa_v1"].(map[string]interface{})
+
+		data.Visas = append(data.Visas, Ga4ghVisaEphemeralResourceModel{
+			Jwt:        types.StringValue(token),
+			KeyId:      types.StringValue(header.Kid),
+			Issuer:     claimString(claims, "iss"),
+			Subject:    claimString(claims, "sub"),
+			IssuedAt:   claimTime(claims, "iat"),
+			ExpiresAt:  claimTime(claims, "exp"),
+			Type:       claimString(visa, "type"),
+			Value:      claimString(visa, "value"),
+			Source:     claimString(visa, "source"),
+			By:         claimString(visa, "by"),
+			Asserted:   claimTime(visa, "asserted"),
+			ClaimsJson: types.StringValue(string(claimsJson)),
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read GA4GH visas", map[string]interface{}{"user": data.User.ValueString(), "visas": len(data.Visas)})
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// claimString is a string claim, null if there is none.
+func claimString(claims map[string]interface{}, name string) types.String {
+	if v, ok := claims[name].(string); ok {
+		return types.StringValue(v)
+	}
+
+	return types.StringNull()
+}
+
+// claimTime is a seconds since the epoch claim in RFC 3339, null if there is none.
+func claimTime(claims map[string]interface{}, name string) types.String {
+	if v, ok := claims[name].(float64); ok {
+		return types.StringValue(time.Unix(int64(v), 0).UTC().Format(time.RFC3339))
+	}
+
+	return types.StringNull()
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeral_resources
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// A deliberately small JWT verifier - REMS signs its visas with RSA keys, so
+// that is all we check.
+
+// jwtHeader is the part of a JWT header the verifier needs.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtLeeway is how far the clocks of REMS and the provider may disagree when
+// checking when a token is valid.
+const jwtLeeway = time.Minute
+
+// jwtExpectations are what the claims of a token must agree with.
+type jwtExpectations struct {
+	// Issuer is the iss a token must have. A trailing slash is ignored.
+	Issuer string
+	// Now is the time the token must be valid at.
+	Now time.Time
+	// AllowExpired accepts a token whose exp has passed.
+	AllowExpired bool
+}
+
+var jwtHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+// verifyJwt checks the signature of token against the keys of a JWKS, and its
+// claims against what is expected, and returns its header and claims.
+func verifyJwt(token string, keys []map[string]interface{}, expected jwtExpectations) (*jwtHeader, map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("not a signed JWT")
+	}
+
+	var header jwtHeader
+
+	if err := decodeJwtPart(parts[0], &header); err != nil {
+		return nil, nil, fmt.Errorf("could not decode header: %w", err)
+	}
+
+	var claims map[string]interface{}
+
+	if err := decodeJwtPart(parts[1], &claims); err != nil {
+		return nil, nil, fmt.Errorf("could not decode claims: %w", err)
+	}
+
+	hash, ok := jwtHashes[header.Alg]
+
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported signing algorithm %q", header.Alg)
+	}
+
+	key, err := jwkRsaKey(keys, header.Kid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not decode signature: %w", err)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+
+	if err := rsa.VerifyPKCS1v15(key, hash, hasher.Sum(nil), signature); err != nil {
+		return nil, nil, fmt.Errorf("signature does not match key %q", header.Kid)
+	}
+
+	if err := checkJwtClaims(claims, expected); err != nil {
+		return nil, nil, err
+	}
+
+	return &header, claims, nil
+}
+
+// checkJwtClaims checks the issuer of a token and that it is valid now, allowing
+// for jwtLeeway either way.
+func checkJwtClaims(claims map[string]interface{}, expected jwtExpectations) error {
+	issuer, _ := claims["iss"].(string)
+
+	if strings.TrimSuffix(issuer, "/") != strings.TrimSuffix(expected.Issuer, "/") {
+		return fmt.Errorf("issued by %q rather than %q", issuer, expected.Issuer)
+	}
+
+	if exp, ok := claims["exp"].(float64); ok && !expected.AllowExpired {
+		if expiry := time.Unix(int64(exp), 0); expected.Now.After(expiry.Add(jwtLeeway)) {
+			return fmt.Errorf("expired at %s", expiry.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if notBefore := time.Unix(int64(nbf), 0); expected.Now.Before(notBefore.Add(-jwtLeeway)) {
+			return fmt.Errorf("not valid until %s", notBefore.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+func decodeJwtPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// jwkRsaKey finds the RSA key with the given id in a JWKS. A token without a key
+// id can only be matched to a set of a single key.
+func jwkRsaKey(keys []map[string]interface{}, kid string) (*rsa.PublicKey, error) {
+	var found map[string]interface{}
+
+	if kid == "" {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("no key id to pick one of the %d keys of the JWKS", len(keys))
+		}
+
+		found = keys[0]
+	}
+
+	for _, k := range keys {
+		if id, _ := k["kid"].(string); kid != "" && id == kid {
+			found = k
+			break
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no key %q in the JWKS", kid)
+	}
+
+	if kty, _ := found["kty"].(string); kty != "RSA" {
+		return nil, fmt.Errorf("key %q is not an RSA key", kid)
+	}
+
+	n, nErr := jwkInt(found, "n")
+	e, eErr := jwkInt(found, "e")
+
+	if nErr != nil || eErr != nil || !e.IsInt64() {
+		return nil, fmt.Errorf("key %q is not a valid RSA key", kid)
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func jwkInt(key map[string]interface{}, name string) (*big.Int, error) {
+	s, ok := key[name].(string)
+
+	if !ok {
+		return nil, fmt.Errorf("missing %s", name)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeral_resources
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testJwk is the JWK of the public half of key.
+func testJwk(key *rsa.PrivateKey, kid string) map[string]interface{} {
+	jwk := map[string]interface{}{
+		"kty": "RSA",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+
+	if kid != "" {
+		jwk["kid"] = kid
+	}
+
+	return jwk
+}
+
+// testJwt is a token of the claims signed with key using RS256.
+func testJwt(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header := map[string]interface{}{"alg": "RS256", "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+
+	encode := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	signed := encode(header) + "." + encode(claims)
+
+	hash := crypto.SHA256.New()
+	hash.Write([]byte(signed))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash.Sum(nil))
+	require.NoError(t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyJwt(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	expected := jwtExpectations{Issuer: "https://rems.example.org", Now: now}
+
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss": "https://rems.example.org/",
+			"sub": "alice",
+			"iat": now.Add(-time.Hour).Unix(),
+			"exp": now.Add(time.Hour).Unix(),
+		}
+		for k, v := range changes {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := map[string]struct {
+		token    string
+		keys     []map[string]interface{}
+		expected jwtExpectations
+		err      string
+	}{
+		"good signature": {
+			token:    testJwt(t, key, "k1", claims(nil)),
+			keys:     []map[string]interface{}{testJwk(other, "k0"), testJwk(key, "k1")},
+			expected: expected,
+		},
+		"bad signature": {
+			token:    testJwt(t, other, "k1", claims(nil)),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+			err:      `signature does not match key "k1"`,
+		},
+		"expired": {
+			token:    testJwt(t, key, "k1", claims(map[string]interface{}{"exp": now.Add(-2 * jwtLeeway).Unix()})),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+			err:      "expired at 2026-10-18T11:58:00Z",
+		},
+		"expired within the leeway": {
+			token:    testJwt(t, key, "k1", claims(map[string]interface{}{"exp": now.Add(-jwtLeeway / 2).Unix()})),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+		},
+		"expired but allowed": {
+			token:    testJwt(t, key, "k1", claims(map[string]interface{}{"exp": now.Add(-24 * time.Hour).Unix()})),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: jwtExpectations{Issuer: expected.Issuer, Now: now, AllowExpired: true},
+		},
+		"not yet valid": {
+			token:    testJwt(t, key, "k1", claims(map[string]interface{}{"nbf": now.Add(2 * jwtLeeway).Unix()})),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+			err:      "not valid until 2026-10-18T12:02:00Z",
+		},
+		"other issuer": {
+			token:    testJwt(t, key, "k1", claims(map[string]interface{}{"iss": "https://evil.example.org"})),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+			err:      `issued by "https://evil.example.org" rather than "https://rems.example.org"`,
+		},
+		"unknown kid": {
+			token:    testJwt(t, key, "k2", claims(nil)),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+			err:      `no key "k2" in the JWKS`,
+		},
+		"no kid with a single key": {
+			token:    testJwt(t, key, "", claims(nil)),
+			keys:     []map[string]interface{}{testJwk(key, "k1")},
+			expected: expected,
+		},
+		"no kid with several keys": {
+			token:    testJwt(t, key, "", claims(nil)),
+			keys:     []map[string]interface{}{testJwk(key, ""), testJwk(other, "")},
+			expected: expected,
+			err:      "no key id to pick one of the 2 keys of the JWKS",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			header, claims, err := verifyJwt(test.token, test.keys, test.expected)
+
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, header)
+			assert.Equal(t, "alice", claims["sub"])
+		})
+	}
+}
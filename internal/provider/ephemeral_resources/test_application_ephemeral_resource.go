@@ -0,0 +1,432 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeral_resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/applications"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient_ext"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &TestApplicationEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &TestApplicationEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &TestApplicationEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &TestApplicationEphemeralResource{}
+
+func NewTestApplicationEphemeralResource() ephemeral.EphemeralResource {
+	return &TestApplicationEphemeralResource{}
+}
+
+// TestApplicationEphemeralResource applies for catalogue items the way an applicant
+// would, to check end to end that their forms and workflows work.
+type TestApplicationEphemeralResource struct {
+	client *remsclient.APIClient
+}
+
+// TestApplicationEphemeralResourceModel describes the ephemeral resource data model.
+type TestApplicationEphemeralResourceModel struct {
+	CatalogueItemIds []types.Int64 `tfsdk:"catalogue_item_ids"`
+	Applicant        types.String  `tfsdk:"applicant"`
+	FieldValues      types.Map     `tfsdk:"field_values"`
+	AcceptLicenses   types.Bool    `tfsdk:"accept_licenses"`
+	Submit           types.Bool    `tfsdk:"submit"`
+	ApproveAs        types.String  `tfsdk:"approve_as"`
+	ApplicationId    types.Int64   `tfsdk:"application_id"`
+	ExternalId       types.String  `tfsdk:"external_id"`
+	State            types.String  `tfsdk:"state"`
+}
+
+// testApplicationPrivate is what Close needs to know of the application Open created.
+type testApplicationPrivate struct {
+	ApplicationId int64  `json:"application_id"`
+	Applicant     string `json:"applicant"`
+	Handler       string `json:"handler"`
+}
+
+const testApplicationPrivateKey = "application"
+
+func (r *TestApplicationEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test_application"
+}
+
+func (r *TestApplicationEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An application made, and once the run ends taken away, to check that catalogue items can be " +
+			"applied for. The application is created as a draft and its fields filled in and licenses accepted. Only if " +
+			"`submit` is `true` is it then submitted and, if `approve_as` is given, approved. Once the run ends a draft is " +
+			"deleted and anything else is closed by the handler.\n\n" +
+			"~> **Warning:** Terraform opens ephemeral resources on every plan as well as every apply, so each " +
+			"`terraform plan` makes an application. With `submit` it is also submitted and approved, which notifies the " +
+			"handlers, shows in their reports and can grant entitlements until it is closed. Point it at REMS " +
+			"instances and catalogue items set aside for testing.",
+
+		Attributes: map[string]schema.Attribute{
+			"catalogue_item_ids": schema.ListAttribute{
+				MarkdownDescription: "Ids of the catalogue items to apply for",
+				ElementType:         types.Int64Type,
+				Required:            true,
+			},
+			"applicant": schema.StringAttribute{
+				MarkdownDescription: "User id to apply as. Defaults to the API user of the provider.",
+				Optional:            true,
+			},
+			"field_values": schema.MapAttribute{
+				MarkdownDescription: "Values of the fields of the application forms, keyed by field id. A value goes into " +
+					"every form of the application with a field of that id.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"accept_licenses": schema.BoolAttribute{
+				MarkdownDescription: "Accept the licenses of the application before submitting it. Defaults to `true`.",
+				Optional:            true,
+			},
+			"submit": schema.BoolAttribute{
+				MarkdownDescription: "Submit the application, and approve it if `approve_as` is given. Defaults to `false`, " +
+					"leaving the application a draft.",
+				Optional: true,
+			},
+			"approve_as": schema.StringAttribute{
+				MarkdownDescription: "User id of a handler to approve the submitted application as. Needs `submit`. The " +
+					"application is only submitted if not given.",
+				Optional: true,
+			},
+			"application_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the application",
+				Computed:            true,
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "External id of the application",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Final state of the application, such as `application.state/approved`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *TestApplicationEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data TestApplicationEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.ApproveAs.IsNull() || data.Submit.IsUnknown() {
+		return
+	}
+
+	if !data.Submit.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("approve_as"),
+			"Missing submit",
+			"An application is only approved once submitted, so approve_as needs submit to be true.",
+		)
+	}
+}
+
+func (r *TestApplicationEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TestApplicationEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TestApplicationEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fieldValues := map[string]string{}
+
+	if !data.FieldValues.IsNull() {
+		resp.Diagnostics.Append(data.FieldValues.ElementsAs(ctx, &fieldValues, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalogueItemIds := make([]int64, 0, len(data.CatalogueItemIds))
+	for _, id := range data.CatalogueItemIds {
+		catalogueItemIds = append(catalogueItemIds, id.ValueInt64())
+	}
+
+	// without users of their own the applicant and handler are the API user
+	apiUser := r.client.GetConfig().DefaultHeader["x-rems-user-id"]
+
+	private := testApplicationPrivate{Applicant: apiUser, Handler: apiUser}
+
+	if !data.Applicant.IsNull() {
+		private.Applicant = data.Applicant.ValueString()
+	}
+
+	if !data.ApproveAs.IsNull() {
+		private.Handler = data.ApproveAs.ValueString()
+	}
+
+	createResult, createResponse, createErr := r.client.ApplicationsAPI.
+		ApiApplicationsCreatePost(context.Background()).
+		XRemsUserId(private.Applicant).
+		CreateApplicationCommand(*remsclient.NewCreateApplicationCommand(catalogueItemIds)).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create application",
+			fmt.Sprintf("Could not create application: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success || createResult.ApplicationId == nil {
+		resp.Diagnostics.AddError(
+			"Failure to create application",
+			fmt.Sprintf("Could not create application: %v", createResult.GetErrors()),
+		)
+		return
+	}
+
+	private.ApplicationId = *createResult.ApplicationId
+
+	resp.Diagnostics.Append(r.apply(ctx, private, fieldValues, data.AcceptLicenses.IsNull() || data.AcceptLicenses.ValueBool(), data.Submit.ValueBool(), !data.ApproveAs.IsNull())...)
+
+	// Close is not called when opening fails, so the application is taken away here
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.discard(ctx, private)...)
+		return
+	}
+
+	application, diags := r.application(private.ApplicationId, private.Applicant)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.discard(ctx, private)...)
+		return
+	}
+
+	data.ApplicationId = types.Int64Value(application.ApplicationId)
+	data.ExternalId = types.StringValue(application.ApplicationExternalId)
+	data.State = types.StringValue(application.ApplicationState)
+
+	privateBytes, err := json.Marshal(private)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to create application", fmt.Sprintf("Could not record application %d: %s", private.ApplicationId, err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, testApplicationPrivateKey, privateBytes)...)
+
+	tflog.Trace(ctx, "created a test application", map[string]interface{}{"application": private.ApplicationId, "state": application.ApplicationState})
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *TestApplicationEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateBytes, diags := req.Private.GetKey(ctx, testApplicationPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || privateBytes == nil {
+		return
+	}
+
+	var private testApplicationPrivate
+
+	if err := json.Unmarshal(privateBytes, &private); err != nil {
+		resp.Diagnostics.AddError("Failure to clean up application", fmt.Sprintf("Could not read the created application: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.discard(ctx, private)...)
+}
+
+// apply fills in and optionally submits and approves the draft application.
+func (r *TestApplicationEphemeralResource) apply(ctx context.Context, private testApplicationPrivate, fieldValues map[string]string, acceptLicenses bool, submit bool, approve bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	application, d := r.application(private.ApplicationId, private.Applicant)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(fieldValues) > 0 {
+		used := map[string]bool{}
+		values := []remsclient_ext.SaveDraftFieldValue{}
+
+		for _, form := range application.ApplicationForms {
+			for _, field := range form.FormFields {
+				if value, ok := fieldValues[field.FieldId]; ok {
+					values = append(values, remsclient_ext.SaveDraftFieldValue{Form: form.FormId, Field: field.FieldId, Value: value})
+					used[field.FieldId] = true
+				}
+			}
+		}
+
+		unused := []string{}
+		for id := range fieldValues {
+			if !used[id] {
+				unused = append(unused, id)
+			}
+		}
+
+		if len(unused) > 0 {
+			sort.Strings(unused)
+			diags.AddError(
+				"Failure to fill in application",
+				fmt.Sprintf("The forms of application %d have no fields %s", private.ApplicationId, strings.Join(unused, ", ")),
+			)
+			return diags
+		}
+
+		result, httpResp, err := remsclient_ext.SaveDraft(context.Background(), r.client, private.Applicant, private.ApplicationId, values)
+
+		diags.Append(applications.CommandDiagnostics("fill in", private.ApplicationId, result, httpResp, err)...)
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if acceptLicenses && len(application.ApplicationLicenses) > 0 {
+		licenses := make([]int64, 0, len(application.ApplicationLicenses))
+		for _, l := range application.ApplicationLicenses {
+			licenses = append(licenses, l.LicenseId)
+		}
+
+		result, httpResp, err := r.client.ApplicationsAPI.
+			ApiApplicationsAcceptLicensesPost(context.Background()).
+			XRemsUserId(private.Applicant).
+			AcceptLicensesCommand(*remsclient.NewAcceptLicensesCommand(private.ApplicationId, licenses)).
+			Execute()
+
+		diags.Append(applications.CommandDiagnostics("accept licenses of", private.ApplicationId, result, httpResp, err)...)
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if !submit {
+		return diags
+	}
+
+	result, httpResp, err := r.client.ApplicationsAPI.
+		ApiApplicationsSubmitPost(context.Background()).
+		XRemsUserId(private.Applicant).
+		SubmitCommand(*remsclient.NewSubmitCommand(private.ApplicationId)).
+		Execute()
+
+	diags.Append(applications.CommandDiagnostics("submit", private.ApplicationId, result, httpResp, err)...)
+
+	if diags.HasError() || !approve {
+		return diags
+	}
+
+	result, httpResp, err = r.client.ApplicationsAPI.
+		ApiApplicationsApprovePost(context.Background()).
+		XRemsUserId(private.Handler).
+		ApproveCommand(*remsclient.NewApproveCommand(private.ApplicationId)).
+		Execute()
+
+	diags.Append(applications.CommandDiagnostics("approve", private.ApplicationId, result, httpResp, err)...)
+
+	return diags
+}
+
+// discard deletes the application if still a draft, otherwise closes it as the handler.
+func (r *TestApplicationEphemeralResource) discard(ctx context.Context, private testApplicationPrivate) diag.Diagnostics {
+	application, diags := r.application(private.ApplicationId, private.Applicant)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	var result *remsclient.SuccessResponse
+	var httpResp *http.Response
+	var err error
+
+	switch application.ApplicationState {
+	case applications.StateDraft:
+		result, httpResp, err = r.client.ApplicationsAPI.
+			ApiApplicationsDeletePost(context.Background()).
+			XRemsUserId(private.Applicant).
+			DeleteCommand(*remsclient.NewDeleteCommand(private.ApplicationId)).
+			Execute()
+
+		diags.Append(applications.CommandDiagnostics("delete", private.ApplicationId, result, httpResp, err)...)
+	case applications.StateSubmitted, applications.StateApproved, applications.StateReturned:
+		result, httpResp, err = r.client.ApplicationsAPI.
+			ApiApplicationsClosePost(context.Background()).
+			XRemsUserId(private.Handler).
+			CloseCommand(*remsclient.NewCloseCommand(private.ApplicationId)).
+			Execute()
+
+		diags.Append(applications.CommandDiagnostics("close", private.ApplicationId, result, httpResp, err)...)
+	case applications.StateRejected, applications.StateRevoked, applications.StateClosed:
+		// done with already
+	default:
+		diags.AddWarning(
+			"Test application not discarded",
+			fmt.Sprintf("Application %d is in state %s, which the provider does not know how to discard. It is left as it is.", private.ApplicationId, application.ApplicationState),
+		)
+	}
+
+	tflog.Trace(ctx, "discarded a test application", map[string]interface{}{"application": private.ApplicationId, "state": application.ApplicationState})
+
+	return diags
+}
+
+// application reads an application as the given user.
+func (r *TestApplicationEphemeralResource) application(applicationId int64, userId string) (*remsclient.Application, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	application, httpResp, err := r.client.ApplicationsAPI.
+		ApiApplicationsApplicationIdGet(context.Background(), applicationId).
+		XRemsUserId(userId).
+		Execute()
+
+	if err != nil {
+		diags.AddError(
+			"Failure to read application",
+			fmt.Sprintf("Could not read application %d: %s %v", applicationId, err.Error(), httpResp),
+		)
+	}
+
+	return application, diags
+}
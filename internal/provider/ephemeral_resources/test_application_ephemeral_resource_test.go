@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ephemeral_resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestApplicationEphemeralResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := NewTestApplicationEphemeralResource().(*TestApplicationEphemeralResource)
+
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := map[string]struct {
+		submit    tftypes.Value
+		approveAs tftypes.Value
+		valid     bool
+	}{
+		"draft":                    {submit: tftypes.NewValue(tftypes.Bool, nil), approveAs: tftypes.NewValue(tftypes.String, nil), valid: true},
+		"submitted":                {submit: tftypes.NewValue(tftypes.Bool, true), approveAs: tftypes.NewValue(tftypes.String, nil), valid: true},
+		"approved":                 {submit: tftypes.NewValue(tftypes.Bool, true), approveAs: tftypes.NewValue(tftypes.String, "handler"), valid: true},
+		"approved, not submitted":  {submit: tftypes.NewValue(tftypes.Bool, nil), approveAs: tftypes.NewValue(tftypes.String, "handler"), valid: false},
+		"approved, submit false":   {submit: tftypes.NewValue(tftypes.Bool, false), approveAs: tftypes.NewValue(tftypes.String, "handler"), valid: false},
+		"approved, submit unknown": {submit: tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue), approveAs: tftypes.NewValue(tftypes.String, "handler"), valid: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			for k, typ := range objectType.AttributeTypes {
+				values[k] = tftypes.NewValue(typ, nil)
+			}
+			values["catalogue_item_ids"] = tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{tftypes.NewValue(tftypes.Number, 1)})
+			values["submit"] = test.submit
+			values["approve_as"] = test.approveAs
+
+			req := ephemeral.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+
+			var resp ephemeral.ValidateConfigResponse
+			r.ValidateConfig(ctx, req, &resp)
+
+			assert.Equal(t, !test.valid, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
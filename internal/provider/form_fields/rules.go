@@ -0,0 +1,418 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package form_fields
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The field types understood by REMS (see rems.common.form in the REMS source).
+const (
+	TypeAttachment  = "attachment"
+	TypeDate        = "date"
+	TypeDescription = "description"
+	TypeEmail       = "email"
+	TypeHeader      = "header"
+	TypeIpAddress   = "ip-address"
+	TypeLabel       = "label"
+	TypeMultiselect = "multiselect"
+	TypeOption      = "option"
+	TypePhoneNumber = "phone-number"
+	TypeTable       = "table"
+	TypeText        = "text"
+	TypeTextArea    = "texta"
+)
+
+// Types lists every field type in the order REMS presents them in its form editor.
+var Types = []string{
+	TypeHeader,
+	TypeLabel,
+	TypeDescription,
+	TypeText,
+	TypeTextArea,
+	TypeOption,
+	TypeMultiselect,
+	TypeTable,
+	TypeDate,
+	TypeEmail,
+	TypePhoneNumber,
+	TypeIpAddress,
+	TypeAttachment,
+}
+
+// The field privacy settings REMS understands.
+const (
+	PrivacyPublic  = "public"
+	PrivacyPrivate = "private"
+)
+
+// The field visibility types REMS understands.
+const (
+	VisibilityAlways = "always"
+	VisibilityOnlyIf = "only-if"
+)
+
+// MaxLengthLimit is the largest max-length REMS will accept for a field.
+const MaxLengthLimit = 32767
+
+// Option is a key/label pair as used for field options and table columns.
+type Option struct {
+	Key   string
+	Label map[string]string
+}
+
+// Visibility is the condition under which a field is shown to the applicant. A
+// nil Values means the values are not known yet.
+type Visibility struct {
+	Type    *string
+	FieldId *string
+	Values  []string
+}
+
+// Field is the provider neutral shape of a form field that the rules operate on.
+//
+// Values that are not yet known at plan time are left nil so that the rules
+// depending on them are skipped. The Has* flags record that an attribute was
+// configured even if its value is not known yet.
+type Field struct {
+	Id        *string
+	Type      *string
+	Title     map[string]string
+	Optional  *bool
+	MaxLength *int64
+
+	HasInfo        bool
+	HasPlaceholder bool
+
+	HasOptions bool
+	Options    []Option
+
+	HasColumns bool
+	Columns    []Option
+
+	Privacy    *string
+	Visibility *Visibility
+}
+
+// Problem is a single rule violation. Index is the position of the field in the
+// list (or -1 when validating a lone field) and Attribute names the offending
+// field attribute using the provider schema names.
+type Problem struct {
+	Index     int
+	Attribute string
+	Summary   string
+	Detail    string
+}
+
+func IsKnownType(t string) bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+func SupportsOptional(t string) bool {
+	return t != TypeLabel && t != TypeHeader
+}
+
+func SupportsInfo(t string) bool {
+	return t != TypeLabel && t != TypeHeader
+}
+
+func SupportsPlaceholder(t string) bool {
+	switch t {
+	case TypeDescription, TypeText, TypeTextArea, TypeEmail, TypePhoneNumber, TypeIpAddress:
+		return true
+	}
+	return false
+}
+
+func SupportsMaxLength(t string) bool {
+	switch t {
+	case TypeDescription, TypeText, TypeTextArea:
+		return true
+	}
+	return false
+}
+
+func SupportsPrivacy(t string) bool {
+	return t != TypeLabel && t != TypeHeader
+}
+
+func SupportsOptions(t string) bool {
+	return t == TypeOption || t == TypeMultiselect
+}
+
+func SupportsColumns(t string) bool {
+	return t == TypeTable
+}
+
+// ValidateField checks the rules that apply to a single field in isolation.
+func ValidateField(index int, f Field) []Problem {
+	var problems []Problem
+
+	add := func(attribute string, summary string, detail string) {
+		problems = append(problems, Problem{Index: index, Attribute: attribute, Summary: summary, Detail: detail})
+	}
+
+	if f.Id != nil && strings.TrimSpace(*f.Id) == "" {
+		add("id", "Invalid field id", "A field id, when given, must not be blank.")
+	}
+
+	if f.Title != nil {
+		if len(f.Title) == 0 {
+			add("title", "Missing field title", "A field title needs a value for at least one language.")
+		}
+		for lang, text := range f.Title {
+			if strings.TrimSpace(text) == "" {
+				add("title", "Missing field title", fmt.Sprintf("The field title for language %q must not be blank.", lang))
+			}
+		}
+	}
+
+	if f.Visibility != nil && f.Visibility.Type != nil {
+		switch *f.Visibility.Type {
+		case VisibilityAlways:
+			if f.Visibility.FieldId != nil || len(f.Visibility.Values) > 0 {
+				add("visibility", "Invalid field visibility",
+					fmt.Sprintf("A visibility of %q does not take a field_id or values.", VisibilityAlways))
+			}
+		case VisibilityOnlyIf:
+			if f.Visibility.FieldId == nil {
+				add("visibility", "Invalid field visibility",
+					fmt.Sprintf("A visibility of %q needs the field_id of the field it depends on.", VisibilityOnlyIf))
+			}
+			if f.Visibility.Values != nil && len(f.Visibility.Values) == 0 {
+				add("visibility", "Invalid field visibility",
+					fmt.Sprintf("A visibility of %q needs at least one value.", VisibilityOnlyIf))
+			}
+		default:
+			add("visibility", "Invalid field visibility",
+				fmt.Sprintf("Visibility type %q must be one of %q or %q.", *f.Visibility.Type, VisibilityAlways, VisibilityOnlyIf))
+		}
+	}
+
+	if f.Privacy != nil && *f.Privacy != PrivacyPublic && *f.Privacy != PrivacyPrivate {
+		add("privacy", "Invalid field privacy",
+			fmt.Sprintf("Privacy %q must be one of %q or %q.", *f.Privacy, PrivacyPublic, PrivacyPrivate))
+	}
+
+	if f.Type == nil {
+		return problems
+	}
+
+	t := *f.Type
+
+	if !IsKnownType(t) {
+		add("type", "Unknown field type",
+			fmt.Sprintf("Field type %q is not one of the types REMS supports: %s.", t, strings.Join(Types, ", ")))
+		return problems
+	}
+
+	if f.Optional != nil && *f.Optional && !SupportsOptional(t) {
+		add("optional", "Unsupported field attribute", fmt.Sprintf("A %s field cannot be optional.", t))
+	}
+
+	if f.HasInfo && !SupportsInfo(t) {
+		add("info", "Unsupported field attribute", fmt.Sprintf("A %s field does not take info text.", t))
+	}
+
+	if f.Privacy != nil && !SupportsPrivacy(t) {
+		add("privacy", "Unsupported field attribute", fmt.Sprintf("A %s field does not take a privacy setting.", t))
+	}
+
+	if f.HasPlaceholder && !SupportsPlaceholder(t) {
+		add("placeholder", "Unsupported field attribute", fmt.Sprintf("A %s field does not take a placeholder.", t))
+	}
+
+	if f.MaxLength != nil {
+		if !SupportsMaxLength(t) {
+			add("max_length", "Unsupported field attribute", fmt.Sprintf("A %s field does not take a max_length.", t))
+		} else if *f.MaxLength < 0 || *f.MaxLength > MaxLengthLimit {
+			add("max_length", "Invalid field max_length",
+				fmt.Sprintf("max_length must be between 0 and %d, got %d.", MaxLengthLimit, *f.MaxLength))
+		}
+	}
+
+	if SupportsOptions(t) {
+		if !f.HasOptions {
+			add("options", "Missing field options", fmt.Sprintf("A %s field needs at least one option.", t))
+		} else {
+			problems = append(problems, validateOptions(index, "options", "option", f.Options)...)
+		}
+	} else if f.HasOptions {
+		add("options", "Unsupported field attribute", fmt.Sprintf("A %s field does not take options.", t))
+	}
+
+	if SupportsColumns(t) {
+		if !f.HasColumns {
+			add("columns", "Missing field columns", fmt.Sprintf("A %s field needs at least one column.", t))
+		} else {
+			problems = append(problems, validateOptions(index, "columns", "column", f.Columns)...)
+		}
+	} else if f.HasColumns {
+		add("columns", "Unsupported field attribute", fmt.Sprintf("A %s field does not take columns.", t))
+	}
+
+	return problems
+}
+
+// ValidateFields checks every field and then the rules that span fields, such as
+// the uniqueness of field ids and the fields that visibility conditions refer to.
+func ValidateFields(fields []Field) []Problem {
+	var problems []Problem
+
+	seenIds := map[string]int{}
+
+	for i, f := range fields {
+		problems = append(problems, ValidateField(i, f)...)
+
+		if f.Id == nil {
+			continue
+		}
+
+		if first, ok := seenIds[*f.Id]; ok {
+			problems = append(problems, Problem{
+				Index:     i,
+				Attribute: "id",
+				Summary:   "Duplicate field id",
+				Detail:    fmt.Sprintf("Field id %q is already used by field %d.", *f.Id, first),
+			})
+		} else {
+			seenIds[*f.Id] = i
+		}
+	}
+
+	for i, f := range fields {
+		if f.Visibility == nil || f.Visibility.FieldId == nil {
+			continue
+		}
+
+		target, ok := seenIds[*f.Visibility.FieldId]
+
+		if !ok {
+			// the field may be one whose id is only generated later, so only an
+			// id that no field could have is an error
+			if !hasFieldWithoutId(fields) {
+				problems = append(problems, Problem{
+					Index:     i,
+					Attribute: "visibility",
+					Summary:   "Invalid field visibility",
+					Detail:    fmt.Sprintf("There is no field with id %q for the visibility to depend on.", *f.Visibility.FieldId),
+				})
+			}
+			continue
+		}
+
+		problems = append(problems, validateVisibilityTarget(i, f.Visibility, fields[target])...)
+	}
+
+	return problems
+}
+
+// validateVisibilityTarget checks that a visibility condition refers to a field
+// with options, and only to values that are keys of those options.
+func validateVisibilityTarget(index int, visibility *Visibility, target Field) []Problem {
+	var problems []Problem
+
+	if target.Type == nil {
+		return problems
+	}
+
+	if !SupportsOptions(*target.Type) {
+		return append(problems, Problem{
+			Index:     index,
+			Attribute: "visibility",
+			Summary:   "Invalid field visibility",
+			Detail: fmt.Sprintf("Visibility can only depend on an %s or %s field, but field %q is a %s field.",
+				TypeOption, TypeMultiselect, *visibility.FieldId, *target.Type),
+		})
+	}
+
+	if target.Options == nil {
+		return problems
+	}
+
+	keys := map[string]bool{}
+	for _, o := range target.Options {
+		keys[o.Key] = true
+	}
+
+	for _, v := range visibility.Values {
+		if !keys[v] {
+			problems = append(problems, Problem{
+				Index:     index,
+				Attribute: "visibility",
+				Summary:   "Invalid field visibility",
+				Detail:    fmt.Sprintf("Value %q is not an option of field %q.", v, *visibility.FieldId),
+			})
+		}
+	}
+
+	return problems
+}
+
+func hasFieldWithoutId(fields []Field) bool {
+	for _, f := range fields {
+		if f.Id == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validateOptions applies the shared rules for option and column lists. A nil
+// list means the elements are not known yet.
+func validateOptions(index int, attribute string, noun string, options []Option) []Problem {
+	var problems []Problem
+
+	if options == nil {
+		return problems
+	}
+
+	if len(options) == 0 {
+		problems = append(problems, Problem{
+			Index:     index,
+			Attribute: attribute,
+			Summary:   fmt.Sprintf("Missing field %s", attribute),
+			Detail:    fmt.Sprintf("At least one %s is required.", noun),
+		})
+	}
+
+	seenKeys := map[string]bool{}
+
+	for _, o := range options {
+		if strings.TrimSpace(o.Key) == "" {
+			problems = append(problems, Problem{
+				Index:     index,
+				Attribute: attribute,
+				Summary:   fmt.Sprintf("Invalid field %s", attribute),
+				Detail:    fmt.Sprintf("Every %s needs a non-blank key.", noun),
+			})
+			continue
+		}
+		if seenKeys[o.Key] {
+			problems = append(problems, Problem{
+				Index:     index,
+				Attribute: attribute,
+				Summary:   fmt.Sprintf("Invalid field %s", attribute),
+				Detail:    fmt.Sprintf("The %s key %q is used more than once.", noun, o.Key),
+			})
+		}
+		seenKeys[o.Key] = true
+		if len(o.Label) == 0 {
+			problems = append(problems, Problem{
+				Index:     index,
+				Attribute: attribute,
+				Summary:   fmt.Sprintf("Invalid field %s", attribute),
+				Detail:    fmt.Sprintf("The %s %q needs a label for at least one language.", noun, o.Key),
+			})
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package form_fields
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// summaries is the "attribute: summary" of each problem, in order.
+func summaries(problems []Problem) []string {
+	result := []string{}
+	for _, p := range problems {
+		result = append(result, p.Attribute+": "+p.Summary)
+	}
+	return result
+}
+
+func TestValidateField(t *testing.T) {
+	option := []Option{{Key: "yes", Label: map[string]string{"en": "Yes"}}}
+
+	tests := map[string]struct {
+		field    Field
+		expected []string
+	}{
+		"valid text": {
+			field:    Field{Id: ptr("fld1"), Type: ptr(TypeText), Title: map[string]string{"en": "Name"}, MaxLength: ptr(int64(100)), HasPlaceholder: true},
+			expected: []string{},
+		},
+		"unknown values are skipped": {
+			field:    Field{},
+			expected: []string{},
+		},
+		"blank id": {
+			field:    Field{Id: ptr(" "), Type: ptr(TypeText)},
+			expected: []string{"id: Invalid field id"},
+		},
+		"empty title": {
+			field:    Field{Type: ptr(TypeText), Title: map[string]string{}},
+			expected: []string{"title: Missing field title"},
+		},
+		"blank title": {
+			field:    Field{Type: ptr(TypeText), Title: map[string]string{"en": ""}},
+			expected: []string{"title: Missing field title"},
+		},
+		"unknown type": {
+			field:    Field{Type: ptr("textfield"), MaxLength: ptr(int64(10))},
+			expected: []string{"type: Unknown field type"},
+		},
+		"optional header": {
+			field:    Field{Type: ptr(TypeHeader), Optional: ptr(true)},
+			expected: []string{"optional: Unsupported field attribute"},
+		},
+		"required header": {
+			field:    Field{Type: ptr(TypeHeader), Optional: ptr(false)},
+			expected: []string{},
+		},
+		"label with info, privacy and placeholder": {
+			field: Field{Type: ptr(TypeLabel), HasInfo: true, HasPlaceholder: true, Privacy: ptr(PrivacyPublic)},
+			expected: []string{
+				"info: Unsupported field attribute",
+				"privacy: Unsupported field attribute",
+				"placeholder: Unsupported field attribute",
+			},
+		},
+		"invalid privacy": {
+			field:    Field{Type: ptr(TypeText), Privacy: ptr("secret")},
+			expected: []string{"privacy: Invalid field privacy"},
+		},
+		"max_length on a header": {
+			field:    Field{Type: ptr(TypeHeader), MaxLength: ptr(int64(10))},
+			expected: []string{"max_length: Unsupported field attribute"},
+		},
+		"max_length out of range": {
+			field:    Field{Type: ptr(TypeTextArea), MaxLength: ptr(int64(MaxLengthLimit + 1))},
+			expected: []string{"max_length: Invalid field max_length"},
+		},
+		"options on a text field": {
+			field:    Field{Type: ptr(TypeText), HasOptions: true, Options: option},
+			expected: []string{"options: Unsupported field attribute"},
+		},
+		"option without options": {
+			field:    Field{Type: ptr(TypeOption)},
+			expected: []string{"options: Missing field options"},
+		},
+		"option with unknown options": {
+			field:    Field{Type: ptr(TypeMultiselect), HasOptions: true},
+			expected: []string{},
+		},
+		"option with empty options": {
+			field:    Field{Type: ptr(TypeOption), HasOptions: true, Options: []Option{}},
+			expected: []string{"options: Missing field options"},
+		},
+		"option keys and labels": {
+			field: Field{Type: ptr(TypeOption), HasOptions: true, Options: []Option{option[0], {Key: " "}, option[0], {Key: "no"}}},
+			expected: []string{
+				"options: Invalid field options",
+				"options: Invalid field options",
+				"options: Invalid field options",
+			},
+		},
+		"table without columns": {
+			field:    Field{Type: ptr(TypeTable)},
+			expected: []string{"columns: Missing field columns"},
+		},
+		"columns on an option field": {
+			field:    Field{Type: ptr(TypeOption), HasOptions: true, Options: option, HasColumns: true, Columns: option},
+			expected: []string{"columns: Unsupported field attribute"},
+		},
+		"always visibility with a field": {
+			field:    Field{Type: ptr(TypeText), Visibility: &Visibility{Type: ptr(VisibilityAlways), FieldId: ptr("fld1")}},
+			expected: []string{"visibility: Invalid field visibility"},
+		},
+		"only-if visibility without a field or values": {
+			field: Field{Type: ptr(TypeText), Visibility: &Visibility{Type: ptr(VisibilityOnlyIf), Values: []string{}}},
+			expected: []string{
+				"visibility: Invalid field visibility",
+				"visibility: Invalid field visibility",
+			},
+		},
+		"unknown visibility type": {
+			field:    Field{Type: ptr(TypeText), Visibility: &Visibility{Type: ptr("never")}},
+			expected: []string{"visibility: Invalid field visibility"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			problems := ValidateField(3, test.field)
+
+			assert.Equal(t, test.expected, summaries(problems))
+
+			for _, p := range problems {
+				assert.Equal(t, 3, p.Index)
+			}
+		})
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	choice := Field{
+		Id:         ptr("choice"),
+		Type:       ptr(TypeOption),
+		HasOptions: true,
+		Options:    []Option{{Key: "yes", Label: map[string]string{"en": "Yes"}}, {Key: "no", Label: map[string]string{"en": "No"}}},
+	}
+	text := func(id *string, visibility *Visibility) Field {
+		return Field{Id: id, Type: ptr(TypeText), Visibility: visibility}
+	}
+	onlyIf := func(fieldId string, values ...string) *Visibility {
+		return &Visibility{Type: ptr(VisibilityOnlyIf), FieldId: ptr(fieldId), Values: values}
+	}
+
+	tests := map[string]struct {
+		fields   []Field
+		expected []Problem
+	}{
+		"valid": {
+			fields:   []Field{choice, text(ptr("details"), onlyIf("choice", "yes"))},
+			expected: nil,
+		},
+		"field problems carry their index": {
+			fields: []Field{choice, {Type: ptr("textfield")}},
+			expected: []Problem{
+				{Index: 1, Attribute: "type", Summary: "Unknown field type", Detail: `Field type "textfield" is not one of the types REMS supports: header, label, description, text, texta, option, multiselect, table, date, email, phone-number, ip-address, attachment.`},
+			},
+		},
+		"duplicate id": {
+			fields: []Field{text(ptr("fld1"), nil), text(ptr("fld2"), nil), text(ptr("fld1"), nil)},
+			expected: []Problem{
+				{Index: 2, Attribute: "id", Summary: "Duplicate field id", Detail: `Field id "fld1" is already used by field 0.`},
+			},
+		},
+		"visibility on a missing field": {
+			fields: []Field{text(ptr("details"), onlyIf("choice", "yes"))},
+			expected: []Problem{
+				{Index: 0, Attribute: "visibility", Summary: "Invalid field visibility", Detail: `There is no field with id "choice" for the visibility to depend on.`},
+			},
+		},
+		"visibility on a field whose id is generated later": {
+			fields:   []Field{text(nil, nil), text(ptr("details"), onlyIf("fld1", "yes"))},
+			expected: nil,
+		},
+		"visibility on a field without options": {
+			fields: []Field{text(ptr("name"), nil), text(ptr("details"), onlyIf("name", "yes"))},
+			expected: []Problem{
+				{Index: 1, Attribute: "visibility", Summary: "Invalid field visibility", Detail: `Visibility can only depend on an option or multiselect field, but field "name" is a text field.`},
+			},
+		},
+		"visibility on a value that is not an option": {
+			fields: []Field{choice, text(ptr("details"), onlyIf("choice", "yes", "maybe"))},
+			expected: []Problem{
+				{Index: 1, Attribute: "visibility", Summary: "Invalid field visibility", Detail: `Value "maybe" is not an option of field "choice".`},
+			},
+		},
+		"visibility on options not known yet": {
+			fields:   []Field{{Id: ptr("choice"), Type: ptr(TypeOption), HasOptions: true}, text(ptr("details"), onlyIf("choice", "maybe"))},
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ValidateFields(test.fields))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package form_fields
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// OptionAttributeTypes is the Terraform type of a field option or table column.
+var OptionAttributeTypes = map[string]attr.Type{
+	"key":   types.StringType,
+	"label": types.MapType{ElemType: types.StringType},
+}
+
+// VisibilityAttributeTypes is the Terraform type of a field visibility condition.
+var VisibilityAttributeTypes = map[string]attr.Type{
+	"type":     types.StringType,
+	"field_id": types.StringType,
+	"values":   types.ListType{ElemType: types.StringType},
+}
+
+// AttributeTypes is the Terraform type of a single entry in the fields of a
+// remscontent_form. It must be kept in step with the form resource schema so
+// that provider functions can return values the resource accepts.
+var AttributeTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"type":        types.StringType,
+	"title":       types.MapType{ElemType: types.StringType},
+	"info":        types.MapType{ElemType: types.StringType},
+	"placeholder": types.MapType{ElemType: types.StringType},
+	"optional":    types.BoolType,
+	"max_length":  types.Int64Type,
+	"options":     types.ListType{ElemType: types.ObjectType{AttrTypes: OptionAttributeTypes}},
+	"columns":     types.ListType{ElemType: types.ObjectType{AttrTypes: OptionAttributeTypes}},
+	"privacy":     types.StringType,
+	"visibility":  types.ObjectType{AttrTypes: VisibilityAttributeTypes},
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package form_fields
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Value is a single entry of the fields of a remscontent_form.
+type Value struct {
+	Id          types.String `tfsdk:"id"`
+	Type        types.String `tfsdk:"type"`
+	Title       types.Map    `tfsdk:"title"`
+	Info        types.Map    `tfsdk:"info"`
+	Placeholder types.Map    `tfsdk:"placeholder"`
+	Optional    types.Bool   `tfsdk:"optional"`
+	MaxLength   types.Int64  `tfsdk:"max_length"`
+	Options     types.List   `tfsdk:"options"`
+	Columns     types.List   `tfsdk:"columns"`
+	Privacy     types.String `tfsdk:"privacy"`
+	Visibility  types.Object `tfsdk:"visibility"`
+}
+
+// NewValue converts a field as sent to REMS into its Terraform value.
+func NewValue(ctx context.Context, f remsclient.NewwFieldTemplate) (Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	optionType := types.ObjectType{AttrTypes: OptionAttributeTypes}
+
+	value := Value{
+		Id:          types.StringPointerValue(f.FieldId),
+		Type:        types.StringValue(f.FieldType),
+		Info:        types.MapNull(types.StringType),
+		Placeholder: types.MapNull(types.StringType),
+		Optional:    types.BoolValue(f.FieldOptional),
+		MaxLength:   types.Int64PointerValue(f.FieldMaxLength.Get()),
+		Options:     types.ListNull(optionType),
+		Columns:     types.ListNull(optionType),
+		Privacy:     types.StringPointerValue(f.FieldPrivacy),
+		Visibility:  types.ObjectNull(VisibilityAttributeTypes),
+	}
+
+	value.Title, d = types.MapValueFrom(ctx, types.StringType, f.FieldTitle)
+	diags.Append(d...)
+
+	if f.FieldInfoText != nil {
+		value.Info, d = types.MapValueFrom(ctx, types.StringType, *f.FieldInfoText)
+		diags.Append(d...)
+	}
+
+	if f.FieldPlaceholder != nil {
+		value.Placeholder, d = types.MapValueFrom(ctx, types.StringType, *f.FieldPlaceholder)
+		diags.Append(d...)
+	}
+
+	if f.FieldOptions != nil {
+		options := make([]Option, 0, len(f.FieldOptions))
+		for _, o := range f.FieldOptions {
+			options = append(options, Option{Key: o.Key, Label: o.Label})
+		}
+		value.Options, d = OptionsValue(ctx, options)
+		diags.Append(d...)
+	}
+
+	if f.FieldColumns != nil {
+		columns := make([]Option, 0, len(f.FieldColumns))
+		for _, c := range f.FieldColumns {
+			columns = append(columns, Option{Key: c.Key, Label: c.Label})
+		}
+		value.Columns, d = OptionsValue(ctx, columns)
+		diags.Append(d...)
+	}
+
+	if f.FieldVisibility != nil {
+		fieldId := types.StringNull()
+		if f.FieldVisibility.VisibilityField != nil {
+			fieldId = types.StringValue(f.FieldVisibility.VisibilityField.FieldId)
+		}
+
+		values := types.ListNull(types.StringType)
+		if f.FieldVisibility.VisibilityValues != nil {
+			values, d = types.ListValueFrom(ctx, types.StringType, f.FieldVisibility.VisibilityValues)
+			diags.Append(d...)
+		}
+
+		value.Visibility, d = types.ObjectValue(VisibilityAttributeTypes, map[string]attr.Value{
+			"type":     types.StringValue(f.FieldVisibility.VisibilityType),
+			"field_id": fieldId,
+			"values":   values,
+		})
+		diags.Append(d...)
+	}
+
+	return value, diags
+}
+
+// TemplatesValue converts the fields of a form as read back from REMS into the
+// fields of a remscontent_form. The defaults REMS fills in are left null, as
+// they would be in a configuration written by hand (or by the generate command),
+// so that importing a form does not plan an update to spell them out.
+func TemplatesValue(ctx context.Context, templates []remsclient.FieldTemplate) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]Value, 0, len(templates))
+
+	for _, template := range templates {
+		// the read and write shapes of a field share their JSON, but not their Go types
+		var f remsclient.NewwFieldTemplate
+
+		data, err := json.Marshal(template)
+
+		if err == nil {
+			err = json.Unmarshal(data, &f)
+		}
+
+		if err != nil {
+			diags.AddError("Failure to convert form field", err.Error())
+			continue
+		}
+
+		value, d := NewValue(ctx, f)
+		diags.Append(d...)
+
+		values = append(values, withoutDefaults(value))
+	}
+
+	if diags.HasError() {
+		return types.ListNull(types.ObjectType{AttrTypes: AttributeTypes}), diags
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: AttributeTypes}, values)
+}
+
+// withoutDefaults nulls the attributes of a field that hold what REMS assumes
+// when they are not given.
+func withoutDefaults(value Value) Value {
+	if len(value.Info.Elements()) == 0 {
+		value.Info = types.MapNull(types.StringType)
+	}
+
+	if len(value.Placeholder.Elements()) == 0 {
+		value.Placeholder = types.MapNull(types.StringType)
+	}
+
+	if !value.Optional.ValueBool() {
+		value.Optional = types.BoolNull()
+	}
+
+	if value.Privacy.ValueString() == PrivacyPublic {
+		value.Privacy = types.StringNull()
+	}
+
+	if attributes := value.Visibility.Attributes(); !value.Visibility.IsNull() &&
+		attributes["type"].Equal(types.StringValue(VisibilityAlways)) &&
+		attributes["field_id"].IsNull() &&
+		attributes["values"].IsNull() {
+		value.Visibility = types.ObjectNull(VisibilityAttributeTypes)
+	}
+
+	return value
+}
+
+// OptionsValue converts options (or table columns) into their Terraform value.
+func OptionsValue(ctx context.Context, options []Option) (types.List, diag.Diagnostics) {
+	optionValues := make([]struct {
+		Key   string            `tfsdk:"key"`
+		Label map[string]string `tfsdk:"label"`
+	}, len(options))
+
+	for i, o := range options {
+		optionValues[i].Key = o.Key
+		optionValues[i].Label = o.Label
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: OptionAttributeTypes}, optionValues)
+}
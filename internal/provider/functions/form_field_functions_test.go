@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFunction calls f with the arguments, returning its result and error.
+func runFunction(t *testing.T, f function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	returnType := definition.Definition.Return.GetType()
+	resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(returnType.(types.ObjectType).AttrTypes))}
+
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func title(values map[string]string) types.Map {
+	elements := map[string]attr.Value{}
+	for k, v := range values {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestFormFieldHeaderFunction(t *testing.T) {
+	result, err := runFunction(t, NewFormFieldHeaderFunction(), types.StringValue("fld1"), title(map[string]string{"en": "Applicant"}))
+	require.Nil(t, err)
+
+	assert.Equal(t, types.ObjectValueMust(
+		map[string]attr.Type{"id": types.StringType, "title": types.MapType{ElemType: types.StringType}, "type": types.StringType, "optional": types.BoolType},
+		map[string]attr.Value{
+			"id":       types.StringValue("fld1"),
+			"title":    title(map[string]string{"en": "Applicant"}),
+			"type":     types.StringValue("header"),
+			"optional": types.BoolValue(false),
+		},
+	), result)
+
+	_, err = runFunction(t, NewFormFieldHeaderFunction(), types.StringValue(" "), title(map[string]string{}))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Invalid field id")
+	assert.Contains(t, err.Error(), "Missing field title")
+}
+
+func TestFormFieldLabelFunction(t *testing.T) {
+	result, err := runFunction(t, NewFormFieldLabelFunction(), title(map[string]string{"en": "Please read", "fi": "Lue"}))
+	require.Nil(t, err)
+
+	assert.Equal(t, types.ObjectValueMust(
+		map[string]attr.Type{"title": types.MapType{ElemType: types.StringType}, "type": types.StringType, "optional": types.BoolType},
+		map[string]attr.Value{
+			"title":    title(map[string]string{"en": "Please read", "fi": "Lue"}),
+			"type":     types.StringValue("label"),
+			"optional": types.BoolValue(false),
+		},
+	), result)
+
+	_, err = runFunction(t, NewFormFieldLabelFunction(), title(map[string]string{"en": ""}))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Missing field title")
+	assert.Equal(t, int64(0), *err.FunctionArgument)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
 )
 
 var (
@@ -51,6 +52,7 @@ func (r FormFieldHeaderFunction) Definition(_ context.Context, _ function.Defini
 func (r FormFieldHeaderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
 	var idData string
 	var titleData map[string]string
+	fieldType := form_fields.TypeHeader
 
 	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &idData, &titleData))
 
@@ -58,6 +60,14 @@ func (r FormFieldHeaderFunction) Run(ctx context.Context, req function.RunReques
 		return
 	}
 
+	resp.Error = validateFormField(
+		form_fields.Field{Id: &idData, Type: &fieldType, Title: titleData},
+		map[string]int64{"id": 0, "title": 1})
+
+	if resp.Error != nil {
+		return
+	}
+
 	result := struct {
 		Id       string            `tfsdk:"id"`
 		Title    map[string]string `tfsdk:"title"`
@@ -66,7 +76,7 @@ func (r FormFieldHeaderFunction) Run(ctx context.Context, req function.RunReques
 	}{
 		Id:    idData,
 		Title: titleData,
-		Type:  "header",
+		Type:  fieldType,
 	}
 
 	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
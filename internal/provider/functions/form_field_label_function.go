@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
 )
 
 var (
@@ -29,13 +30,14 @@ func (r FormFieldLabelFunction) Definition(_ context.Context, _ function.Definit
 	resp.Definition = function.Definition{
 		Summary: "Field template for a label",
 		Parameters: []function.Parameter{
-			function.StringParameter{
-				Name: "title",
+			function.MapParameter{
+				ElementType: types.StringType,
+				Name:        "title",
 			},
 		},
 		Return: function.ObjectReturn{
 			AttributeTypes: map[string]attr.Type{
-				"title":    types.StringType,
+				"title":    types.MapType{ElemType: types.StringType},
 				"type":     types.StringType,
 				"optional": types.BoolType,
 			},
@@ -44,21 +46,30 @@ func (r FormFieldLabelFunction) Definition(_ context.Context, _ function.Definit
 }
 
 func (r FormFieldLabelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
-	var functionData string
+	var titleData map[string]string
+	fieldType := form_fields.TypeLabel
 
-	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &functionData))
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &titleData))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = validateFormField(
+		form_fields.Field{Type: &fieldType, Title: titleData},
+		map[string]int64{"title": 0})
 
 	if resp.Error != nil {
 		return
 	}
 
 	result := struct {
-		Title    string `tfsdk:"title"`
-		Type     string `tfsdk:"type"`
-		Optional bool   `tfsdk:"optional"`
+		Title    map[string]string `tfsdk:"title"`
+		Type     string            `tfsdk:"type"`
+		Optional bool              `tfsdk:"optional"`
 	}{
-		Title: functionData,
-		Type:  "label",
+		Title: titleData,
+		Type:  fieldType,
 	}
 
 	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
+)
+
+// validateFormField runs the form field rules against a field built by one of the
+// field functions. Problems are reported against the function argument they came
+// from (as given by arguments, keyed by field attribute name) so that Terraform
+// can point at the offending argument.
+func validateFormField(field form_fields.Field, arguments map[string]int64) *function.FuncError {
+	var funcError *function.FuncError
+
+	for _, problem := range form_fields.ValidateField(-1, field) {
+		message := fmt.Sprintf("%s: %s", problem.Summary, problem.Detail)
+
+		if position, ok := arguments[problem.Attribute]; ok {
+			funcError = function.ConcatFuncErrors(funcError, function.NewArgumentFuncError(position, message))
+		} else {
+			funcError = function.ConcatFuncErrors(funcError, function.NewFuncError(message))
+		}
+	}
+
+	return funcError
+}
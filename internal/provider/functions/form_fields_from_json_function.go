@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+var (
+	_ function.Function = FormFieldsFromJsonFunction{}
+)
+
+func NewFormFieldsFromJsonFunction() function.Function {
+	return FormFieldsFromJsonFunction{}
+}
+
+type FormFieldsFromJsonFunction struct{}
+
+func (r FormFieldsFromJsonFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "form_fields_from_json"
+}
+
+func (r FormFieldsFromJsonFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Form fields from a REMS form export",
+		MarkdownDescription: "Converts REMS form JSON into the `fields` of a `remscontent_form`. " +
+			"Accepts either a whole form as returned by the REMS API (using its `form/fields`) or just the list of fields.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "REMS form or form fields JSON",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: form_fields.AttributeTypes},
+		},
+	}
+}
+
+func (r FormFieldsFromJsonFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jsonData string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jsonData))
+
+	if resp.Error != nil {
+		return
+	}
+
+	rawFields, err := formFieldsJson([]byte(jsonData))
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	remsFields := make([]remsclient.NewwFieldTemplate, len(rawFields))
+	ruleFields := make([]form_fields.Field, len(rawFields))
+
+	for i, rawField := range rawFields {
+		if err := json.Unmarshal(rawField, &remsFields[i]); err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Field %d could not be parsed: %s", i, err.Error()))
+			return
+		}
+		ruleFields[i] = ruleField(remsFields[i])
+	}
+
+	for _, problem := range form_fields.ValidateFields(ruleFields) {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0,
+			fmt.Sprintf("Field %d %s: %s: %s", problem.Index, problem.Attribute, problem.Summary, problem.Detail)))
+	}
+
+	if resp.Error != nil {
+		return
+	}
+
+	result := make([]form_fields.Value, len(remsFields))
+
+	for i, remsField := range remsFields {
+		value, diags := form_fields.NewValue(ctx, remsField)
+
+		if diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+
+		result[i] = value
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// formFieldsJson finds the list of fields in either a whole form or a bare list.
+func formFieldsJson(data []byte) ([]json.RawMessage, error) {
+	var fields []json.RawMessage
+
+	trimmed := bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &fields); err != nil {
+			return nil, fmt.Errorf("Form fields JSON could not be parsed: %s", err.Error())
+		}
+		return fields, nil
+	}
+
+	var form struct {
+		FormFields *[]json.RawMessage `json:"form/fields"`
+	}
+
+	if err := json.Unmarshal(trimmed, &form); err != nil {
+		return nil, fmt.Errorf("Form JSON could not be parsed: %s", err.Error())
+	}
+
+	if form.FormFields == nil {
+		return nil, fmt.Errorf("Form JSON has no \"form/fields\"")
+	}
+
+	return *form.FormFields, nil
+}
+
+func ruleField(f remsclient.NewwFieldTemplate) form_fields.Field {
+	field := form_fields.Field{
+		Id:             f.FieldId,
+		Type:           &f.FieldType,
+		Title:          f.FieldTitle,
+		Optional:       &f.FieldOptional,
+		MaxLength:      f.FieldMaxLength.Get(),
+		HasInfo:        f.FieldInfoText != nil,
+		HasPlaceholder: f.FieldPlaceholder != nil,
+		HasOptions:     f.FieldOptions != nil,
+		HasColumns:     f.FieldColumns != nil,
+		Privacy:        f.FieldPrivacy,
+	}
+
+	if field.Title == nil {
+		field.Title = map[string]string{}
+	}
+
+	for _, o := range f.FieldOptions {
+		field.Options = append(field.Options, form_fields.Option{Key: o.Key, Label: o.Label})
+	}
+
+	for _, c := range f.FieldColumns {
+		field.Columns = append(field.Columns, form_fields.Option{Key: c.Key, Label: c.Label})
+	}
+
+	if f.FieldVisibility != nil {
+		field.Visibility = &form_fields.Visibility{
+			Type:   &f.FieldVisibility.VisibilityType,
+			Values: f.FieldVisibility.VisibilityValues,
+		}
+		if field.Visibility.Values == nil {
+			field.Visibility.Values = []string{}
+		}
+		if f.FieldVisibility.VisibilityField != nil {
+			field.Visibility.FieldId = &f.FieldVisibility.VisibilityField.FieldId
+		}
+	}
+
+	return field
+}
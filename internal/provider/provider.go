@@ -5,16 +5,18 @@ package provider
 
 import (
 	"context"
-	"net/http"
 
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/actions"
 	"github.com/umccr/terraform-provider-remscontent/internal/provider/data_sources"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/ephemeral_resources"
 	"github.com/umccr/terraform-provider-remscontent/internal/provider/functions"
 	"github.com/umccr/terraform-provider-remscontent/internal/provider/resources"
-	remsclient "github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,6 +27,8 @@ import (
 var _ provider.Provider = &RemsContentProvider{}
 var _ provider.ProviderWithFunctions = &RemsContentProvider{}
 var _ provider.ProviderWithEphemeralResources = &RemsContentProvider{}
+var _ provider.ProviderWithListResources = &RemsContentProvider{}
+var _ provider.ProviderWithActions = &RemsContentProvider{}
 
 // RemsContentProvider defines the provider implementation.
 type RemsContentProvider struct {
@@ -78,54 +82,72 @@ func (p *RemsContentProvider) Configure(ctx context.Context, req provider.Config
 	// Configuration values are now available.
 	// if data.Endpoint.IsNull() { /* ... */ }
 
-	// configure a client to hit the authenticated endpoint
-	cfg := remsclient.NewConfiguration()
-	cfg.Host = data.Endpoint.ValueString()
-	cfg.Scheme = "https"
-	cfg.DefaultHeader = map[string]string{
-		"x-rems-user-id": data.ApiUser.ValueString(),
-		"x-rems-api-key": data.ApiKey.ValueString(),
-		"Content-Type":   "application/json",
-	}
-
-	//transport := &BasePathRoundTripper{
-	//	BasePath: "/api/",
-	//	Base:     http.DefaultTransport,
-	//}
-
-	//transport := &BasePathRoundTripper{
-	//	BasePath: "/api/",
-	//	Base:     &DebugRoundTripper{Base: http.DefaultTransport, Ctx: ctx},
-	//}
-
-	cfg.HTTPClient = &http.Client{
-		//	Transport: transport,
-	}
-
-	client := remsclient.NewAPIClient(cfg)
+	client := NewClient(data.Endpoint.ValueString(), data.ApiUser.ValueString(), data.ApiKey.ValueString())
 
 	resp.DataSourceData = client
+	resp.EphemeralResourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+	resp.ActionData = client
 }
 
 func (p *RemsContentProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		resources.NewBlacklistEntryResource,
 		resources.NewCatalogueItemResource,
 		resources.NewCategoryResource,
 		resources.NewFormResource,
+		resources.NewInvitationResource,
 		resources.NewLicenseResource,
+		resources.NewLicenseAttachmentResource,
 		resources.NewResourceResource,
+		resources.NewUserResource,
 		resources.NewWorkflowResource,
 	}
 }
 
+func (p *RemsContentProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		resources.NewCatalogueItemListResource,
+		resources.NewFormListResource,
+		resources.NewLicenseListResource,
+		resources.NewResourceListResource,
+		resources.NewWorkflowListResource,
+	}
+}
+
+func (p *RemsContentProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		actions.NewCloseStaleDraftsAction,
+		actions.NewRevokeApplicationAction,
+		actions.NewSendRemindersAction,
+	}
+}
+
 func (p *RemsContentProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		ephemeral_resources.NewApiUserEphemeralResource,
+		ephemeral_resources.NewGa4ghVisasEphemeralResource,
+		ephemeral_resources.NewTestApplicationEphemeralResource,
+	}
 }
 
 func (p *RemsContentProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		data_sources.NewApplicationsDataSource,
+		data_sources.NewApplicationsExportDataSource,
+		data_sources.NewAuditLogDataSource,
+		data_sources.NewBlacklistDataSource,
+		data_sources.NewCatalogueDataSource,
+		data_sources.NewCatalogueTreeDataSource,
+		data_sources.NewConfigDataSource,
+		data_sources.NewDuoCodesDataSource,
+		data_sources.NewEntitlementsDataSource,
+		data_sources.NewEntitlementsCsvDataSource,
+		data_sources.NewJwksDataSource,
+		data_sources.NewMondoCodesDataSource,
 		data_sources.NewOrganizationDataSource,
+		data_sources.NewWorkflowActorsDataSource,
 	}
 }
 
@@ -135,6 +157,7 @@ func (p *RemsContentProvider) Functions(ctx context.Context) []func() function.F
 	return []func() function.Function{
 		functions.NewFormFieldHeaderFunction,
 		functions.NewFormFieldLabelFunction,
+		functions.NewFormFieldsFromJsonFunction,
 	}
 }
 
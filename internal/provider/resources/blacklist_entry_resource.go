@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlacklistEntryResource{}
+var _ resource.ResourceWithImportState = &BlacklistEntryResource{}
+var _ resource.ResourceWithIdentity = &BlacklistEntryResource{}
+
+// blacklistEntryRemoveComment is the comment of a removal when none is configured.
+const blacklistEntryRemoveComment = "Removed by Terraform"
+
+func NewBlacklistEntryResource() resource.Resource {
+	return &BlacklistEntryResource{}
+}
+
+// BlacklistEntryResource defines the resource implementation.
+type BlacklistEntryResource struct {
+	client *remsclient.APIClient
+}
+
+// BlacklistEntryResourceModel describes the resource data model.
+type BlacklistEntryResourceModel struct {
+	UserId        types.String `tfsdk:"user_id"`
+	ResourceResid types.String `tfsdk:"resource_resid"`
+	Comment       types.String `tfsdk:"comment"`
+	RemoveComment types.String `tfsdk:"remove_comment"`
+	AddedBy       types.String `tfsdk:"added_by"`
+	AddedAt       types.String `tfsdk:"added_at"`
+}
+
+func (r *BlacklistEntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blacklist_entry"
+}
+
+func (r *BlacklistEntryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Blacklist entry, barring a user from applying for a resource. REMS does not allow entries to be " +
+			"edited so any change replaces the entry.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "User id of the blacklisted user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_resid": schema.StringAttribute{
+				MarkdownDescription: "External identifier (`resid`) of the resource the user is blacklisted from",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Reason for the blacklisting",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"remove_comment": schema.StringAttribute{
+				MarkdownDescription: "Reason recorded by REMS when the entry is removed, on destroy or replacement. Defaults to `" + blacklistEntryRemoveComment + "`",
+				Optional:            true,
+			},
+			"added_by": schema.StringAttribute{
+				MarkdownDescription: "User id of whoever added the entry",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"added_at": schema.StringAttribute{
+				MarkdownDescription: "When the entry was added (RFC 3339)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BlacklistEntryResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = blacklistEntryIdentitySchema
+}
+
+func (r *BlacklistEntryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BlacklistEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BlacklistEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addResult, addResponse, addErr := r.client.BlacklistAPI.
+		ApiBlacklistAddPost(context.Background()).
+		BlacklistCommand(data.command()).
+		Execute()
+
+	if addErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to add blacklist entry",
+			fmt.Sprintf("Could not blacklist user %s from resource %s: %s %v", data.UserId.ValueString(), data.ResourceResid.ValueString(), addErr.Error(), addResponse),
+		)
+		return
+	}
+
+	if !addResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to add blacklist entry",
+			fmt.Sprintf("Could not blacklist user %s from resource %s: %v", data.UserId.ValueString(), data.ResourceResid.ValueString(), addResult.GetErrors()),
+		)
+		return
+	}
+
+	// the add returns nothing of the entry, so read back who added it and when
+	entry, err := r.entry(data)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read blacklist entry", err.Error())
+		return
+	}
+
+	if entry != nil {
+		data.fromEntry(entry)
+	} else {
+		data.AddedBy = types.StringNull()
+		data.AddedAt = types.StringNull()
+	}
+
+	tflog.Trace(ctx, "created a blacklist entry")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *BlacklistEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BlacklistEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entry, err := r.entry(data)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read blacklist entry", err.Error())
+		return
+	}
+
+	if entry == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.fromEntry(entry)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *BlacklistEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every attribute but remove_comment requires replacement, and that is only
+	// sent to REMS when the entry is removed
+	var data BlacklistEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *BlacklistEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BlacklistEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removeResult, removeResponse, removeErr := r.client.BlacklistAPI.
+		ApiBlacklistRemovePost(context.Background()).
+		BlacklistCommand(data.removeCommand()).
+		Execute()
+
+	if removeErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to remove blacklist entry",
+			fmt.Sprintf("Could not remove user %s from the blacklist of resource %s: %s %v", data.UserId.ValueString(), data.ResourceResid.ValueString(), removeErr.Error(), removeResponse),
+		)
+		return
+	}
+
+	if !removeResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to remove blacklist entry",
+			fmt.Sprintf("Could not remove user %s from the blacklist of resource %s: %v", data.UserId.ValueString(), data.ResourceResid.ValueString(), removeResult.GetErrors()),
+		)
+	}
+}
+
+// ImportState takes either an identity or an id of the form <user_id>,<resource_resid>.
+func (r *BlacklistEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("user_id"), path.Root("user_id"), req, resp)
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("resource_resid"), path.Root("resource_resid"), req, resp)
+		return
+	}
+
+	userId, resid, ok := strings.Cut(req.ID, ",")
+
+	if !ok || userId == "" || resid == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			fmt.Sprintf("Expected an import identifier of the form <user_id>,<resource_resid>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_resid"), resid)...)
+}
+
+// entry finds the blacklist entry of the model in REMS, returning nil if there is none.
+func (r *BlacklistEntryResource) entry(data BlacklistEntryResourceModel) (*remsclient.BlacklistEntryWithDetails, error) {
+	entries, entriesResponse, entriesErr := r.client.BlacklistAPI.
+		ApiBlacklistGet(context.Background()).
+		User(data.UserId.ValueString()).
+		Resource(data.ResourceResid.ValueString()).
+		Execute()
+
+	if entriesErr != nil {
+		return nil, fmt.Errorf("Could not read the blacklist of user %s for resource %s: %s %v", data.UserId.ValueString(), data.ResourceResid.ValueString(), entriesErr.Error(), entriesResponse)
+	}
+
+	for i := range entries {
+		if entries[i].BlacklistUser.Userid == data.UserId.ValueString() && entries[i].BlacklistResource.ResourceExtId == data.ResourceResid.ValueString() {
+			return &entries[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// fromEntry sets the model from a blacklist entry as returned by REMS.
+func (data *BlacklistEntryResourceModel) fromEntry(entry *remsclient.BlacklistEntryWithDetails) {
+	data.UserId = types.StringValue(entry.BlacklistUser.Userid)
+	data.ResourceResid = types.StringValue(entry.BlacklistResource.ResourceExtId)
+	data.Comment = types.StringValue(entry.BlacklistComment)
+	data.AddedBy = types.StringValue(entry.BlacklistAddedBy.Userid)
+	data.AddedAt = types.StringValue(entry.BlacklistAddedAt.Format(time.RFC3339))
+}
+
+func (data *BlacklistEntryResourceModel) command() remsclient.BlacklistCommand {
+	return *remsclient.NewBlacklistCommand(
+		*remsclient.NewBlacklistCommandResource(data.ResourceResid.ValueString()),
+		*remsclient.NewUser(data.UserId.ValueString()),
+		data.Comment.ValueString(),
+	)
+}
+
+// removeCommand is the command removing the entry. REMS records the comment of a
+// removal alongside the one given when the entry was added.
+func (data *BlacklistEntryResourceModel) removeCommand() remsclient.BlacklistCommand {
+	command := data.command()
+
+	command.Comment = blacklistEntryRemoveComment
+	if !data.RemoveComment.IsNull() && !data.RemoveComment.IsUnknown() {
+		command.Comment = data.RemoveComment.ValueString()
+	}
+
+	return command
+}
+
+func (data *BlacklistEntryResourceModel) identity() blacklistEntryIdentityModel {
+	return blacklistEntryIdentityModel{
+		UserId:        data.UserId,
+		ResourceResid: data.ResourceResid,
+		Type:          types.StringValue(identityTypeBlacklistEntry),
+	}
+}
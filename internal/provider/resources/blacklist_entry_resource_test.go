@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlacklistEntryResourceDelete(t *testing.T) {
+	tests := map[string]struct {
+		removeComment types.String
+		expected      string
+	}{
+		"configured": {
+			removeComment: types.StringValue("Reinstated by the DAC"),
+			expected:      "Reinstated by the DAC",
+		},
+		"not configured, or imported": {
+			removeComment: types.StringNull(),
+			expected:      blacklistEntryRemoveComment,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := newFakeRems(t, map[string]string{
+				"POST /api/blacklist/remove": `{"success": true}`,
+			})
+			r := newTestResource(t, NewBlacklistEntryResource(), f)
+
+			requireNoErrors(t, r.Delete(t, BlacklistEntryResourceModel{
+				UserId:        types.StringValue("alice"),
+				ResourceResid: types.StringValue("urn:example:dataset:1"),
+				Comment:       types.StringValue("Breach of the data transfer agreement"),
+				RemoveComment: test.removeComment,
+				AddedBy:       types.StringValue("owner"),
+				AddedAt:       types.StringValue("2024-07-01T00:00:00Z"),
+			}))
+
+			body := f.Request(t, "POST /api/blacklist/remove").Body
+			assert.Equal(t, test.expected, body["comment"])
+			assert.Equal(t, map[string]interface{}{"userid": "alice"}, body["blacklist/user"])
+		})
+	}
+}
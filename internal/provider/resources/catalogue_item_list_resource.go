@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &CatalogueItemListResource{}
+var _ list.ListResourceWithConfigure = &CatalogueItemListResource{}
+
+func NewCatalogueItemListResource() list.ListResource {
+	return &CatalogueItemListResource{}
+}
+
+// CatalogueItemListResource lists the catalogue items of a REMS instance.
+type CatalogueItemListResource struct {
+	client *remsclient.APIClient
+}
+
+// CatalogueItemListResourceModel describes the list configuration.
+type CatalogueItemListResourceModel struct {
+	listFilterModel
+	Expired types.Bool `tfsdk:"expired"`
+}
+
+func (r *CatalogueItemListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalogue_item"
+}
+
+func (r *CatalogueItemListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := listFilterAttributes("catalogue items")
+	attributes["expired"] = listschema.BoolAttribute{
+		MarkdownDescription: "Include expired catalogue items (those ended by a change of workflow or form)",
+		Optional:            true,
+	}
+
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists catalogue items",
+		Attributes:          attributes,
+	}
+}
+
+func (r *CatalogueItemListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = listClient(req, resp)
+}
+
+func (r *CatalogueItemListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter CatalogueItemListResourceModel
+
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	items, itemsResponse, itemsErr := r.client.CatalogueItemsAPI.
+		ApiCatalogueItemsGet(ctx).
+		Archived(filter.Archived.ValueBool()).
+		Disabled(filter.Disabled.ValueBool()).
+		Expired(filter.Expired.ValueBool()).
+		Execute()
+
+	if itemsErr != nil {
+		listHttpError(stream, "catalogue items", itemsErr, itemsResponse)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, item := range items {
+			if !filter.includes(item.Organization.OrganizationId) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+
+			titles := map[string]string{}
+			for lang, l := range item.Localizations {
+				titles[lang] = l.Title
+			}
+
+			result.DisplayName = LocalizedText(titles)
+
+			identity := newOrganizationObjectIdentity(identityTypeCatalogueItem, types.Int64Value(item.Id), types.StringValue(item.Organization.OrganizationId))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				var data CatalogueItemResourceModel
+				result.Diagnostics.Append(data.fromCatalogueItem(ctx, &item)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
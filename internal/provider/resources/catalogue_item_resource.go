@@ -8,19 +8,21 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CatalogueItemResource{}
 var _ resource.ResourceWithImportState = &CatalogueItemResource{}
+var _ resource.ResourceWithIdentity = &CatalogueItemResource{}
 
 func NewCatalogueItemResource() resource.Resource {
 	return &CatalogueItemResource{}
@@ -28,14 +30,30 @@ func NewCatalogueItemResource() resource.Resource {
 
 // CatalogueItemResource defines the resource implementation.
 type CatalogueItemResource struct {
-	client *http.Client
+	client *remsclient.APIClient
 }
 
+// CatalogueItemLocalizationResourceModel is the title and info link of a catalogue item in a single language.
+type CatalogueItemLocalizationResourceModel struct {
+	Title   types.String `tfsdk:"title"`
+	Infourl types.String `tfsdk:"infourl"`
+}
+
+// catalogueItemLocalizationType is the Terraform type of a CatalogueItemLocalizationResourceModel.
+var catalogueItemLocalizationType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"title":   types.StringType,
+	"infourl": types.StringType,
+}}
+
 // CatalogueItemResourceModel describes the resource data model.
 type CatalogueItemResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	Id             types.Int64  `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	ResourceId     types.Int64  `tfsdk:"resource_id"`
+	WorkflowId     types.Int64  `tfsdk:"workflow_id"`
+	FormId         types.Int64  `tfsdk:"form_id"`
+	Localizations  types.Map    `tfsdk:"localizations"`
+	Categories     types.List   `tfsdk:"categories"`
 }
 
 func (r *CatalogueItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,42 +62,82 @@ func (r *CatalogueItemResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *CatalogueItemResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Catalogue item resource",
+		MarkdownDescription: "Catalogue item resource. REMS only changes the resource, workflow or form of a catalogue " +
+			"item by ending it and creating a copy, so changing any of them replaces the catalogue item.",
 
 		Attributes: map[string]schema.Attribute{
-			"configurable_attribute": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
-				Optional:            true,
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Catalogue item internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization that owns the catalogue item",
+				Required:            true,
 			},
-			"defaulted": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute with default value",
+			"resource_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the resource applied for",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"workflow_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the workflow applications go through",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"form_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of a form the applicant must fill in, in addition to any forms of the workflow",
 				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString("example value when not configured"),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
-			"id": schema.StringAttribute{
-				Computed:            true,
-				MarkdownDescription: "Example identifier",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+			"localizations": schema.MapNestedAttribute{
+				MarkdownDescription: "Catalogue item title and info link keyed by language",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							Required: true,
+						},
+						"infourl": schema.StringAttribute{
+							MarkdownDescription: "Link to more information about the resource",
+							Optional:            true,
+						},
+					},
 				},
 			},
+			"categories": schema.ListAttribute{
+				MarkdownDescription: "Ids of the categories the catalogue item is listed under",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
 		},
 	}
 }
 
+func (r *CatalogueItemResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationObjectIdentitySchema
+}
+
 func (r *CatalogueItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*remsclient.APIClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -98,24 +156,50 @@ func (r *CatalogueItemResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	localizations, categories, diags := data.localizationsAndCategories(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgId := remsclient.NewOrganizationId(data.OrganizationId.ValueString())
+
+	itemConfig := remsclient.NewCreateCatalogueItemCommand(data.ResourceId.ValueInt64(), data.WorkflowId.ValueInt64(), *orgId, localizations)
+	itemConfig.Categories = categories
+
+	if !data.FormId.IsNull() {
+		itemConfig.SetForm(data.FormId.ValueInt64())
+	}
+
+	createResult, createResponse, createErr := r.client.CatalogueItemsAPI.
+		ApiCatalogueItemsCreatePost(context.Background()).
+		CreateCatalogueItemCommand(*itemConfig).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create catalogue item",
+			fmt.Sprintf("Could not create catalogue item: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to create catalogue item",
+			fmt.Sprintf("Could not create catalogue item: %v", createResult.GetErrors()),
+		)
+		return
+	}
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	data.Id = types.Int64Value(createResult.GetId())
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	tflog.Trace(ctx, "created a catalogue item")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *CatalogueItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -128,16 +212,44 @@ func (r *CatalogueItemResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	item, itemResponse, itemErr := r.client.CatalogueItemsAPI.
+		ApiCatalogueItemsItemIdGet(context.Background(), data.Id.ValueInt64()).
+		Execute()
+
+	if itemResponse != nil && itemResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if itemErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read catalogue item",
+			fmt.Sprintf("Could not read catalogue item %d: %s %v", data.Id.ValueInt64(), itemErr.Error(), itemResponse),
+		)
+		return
+	}
+
+	// an archived (or ended) catalogue item is as good as deleted
+	if item.Archived || item.Expired {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkImportedOrganization(ctx, data.OrganizationId, req.Identity, item.Organization.OrganizationId)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromCatalogueItem(ctx, item)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *CatalogueItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -150,16 +262,42 @@ func (r *CatalogueItemResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	localizations, categories, diags := data.localizationsAndCategories(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the organization, localizations and categories can be changed in place
+	itemConfig := remsclient.NewEditCatalogueItemCommand(data.Id.ValueInt64(), localizations)
+	itemConfig.SetOrganization(*remsclient.NewOrganizationId(data.OrganizationId.ValueString()))
+	itemConfig.Categories = categories
+
+	editResult, editResponse, editErr := r.client.CatalogueItemsAPI.
+		ApiCatalogueItemsEditPut(context.Background()).
+		EditCatalogueItemCommand(*itemConfig).
+		Execute()
+
+	if editErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to edit catalogue item",
+			fmt.Sprintf("Could not edit catalogue item %d: %s %v", data.Id.ValueInt64(), editErr.Error(), editResponse),
+		)
+		return
+	}
+
+	if !editResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to edit catalogue item",
+			fmt.Sprintf("Could not edit catalogue item %d: %v", data.Id.ValueInt64(), editResult.GetErrors()),
+		)
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *CatalogueItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -172,15 +310,94 @@ func (r *CatalogueItemResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	// REMS never deletes catalogue items (applications refer to them) - the best we can do is archive
+	archiveResult, archiveResponse, archiveErr := r.client.CatalogueItemsAPI.
+		ApiCatalogueItemsArchivedPut(context.Background()).
+		ArchivedCommand(*remsclient.NewArchivedCommand(data.Id.ValueInt64(), true)).
+		Execute()
+
+	if archiveErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to archive catalogue item",
+			fmt.Sprintf("Could not archive catalogue item %d: %s %v", data.Id.ValueInt64(), archiveErr.Error(), archiveResponse),
+		)
+		return
+	}
+
+	if !archiveResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to archive catalogue item",
+			fmt.Sprintf("Could not archive catalogue item %d: %v", data.Id.ValueInt64(), archiveResult.GetErrors()),
+		)
+	}
 }
 
 func (r *CatalogueItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateInt64Id(ctx, identityTypeCatalogueItem, req, resp)
+}
+
+// fromCatalogueItem sets the model from a catalogue item as returned by REMS.
+func (data *CatalogueItemResourceModel) fromCatalogueItem(ctx context.Context, item *remsclient.CatalogueItem) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.Id = types.Int64Value(item.Id)
+	data.OrganizationId = types.StringValue(item.Organization.OrganizationId)
+	data.ResourceId = types.Int64Value(item.ResourceId)
+	data.WorkflowId = types.Int64Value(item.Wfid)
+	data.FormId = types.Int64PointerValue(item.Formid.Get())
+
+	localizations := map[string]CatalogueItemLocalizationResourceModel{}
+
+	for lang, l := range item.Localizations {
+		localizations[lang] = CatalogueItemLocalizationResourceModel{
+			Title:   types.StringValue(l.Title),
+			Infourl: types.StringPointerValue(l.Infourl.Get()),
+		}
+	}
+
+	data.Localizations, d = types.MapValueFrom(ctx, catalogueItemLocalizationType, localizations)
+	diags.Append(d...)
+
+	categories := make([]int64, 0, len(item.Categories))
+	for _, c := range item.Categories {
+		categories = append(categories, c.CategoryId)
+	}
+
+	data.Categories = listValueUnlessUnset(ctx, types.Int64Type, data.Categories, categories, &diags)
+
+	return diags
+}
+
+func (data *CatalogueItemResourceModel) identity() organizationObjectIdentityModel {
+	return newOrganizationObjectIdentity(identityTypeCatalogueItem, data.Id, data.OrganizationId)
+}
+
+// localizationsAndCategories converts the model into the shapes shared by the create and edit commands.
+func (data *CatalogueItemResourceModel) localizationsAndCategories(ctx context.Context) (map[string]remsclient.CatalogueItemLocalization, []remsclient.CategoryId, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	modelLocalizations := map[string]CatalogueItemLocalizationResourceModel{}
+	diags.Append(data.Localizations.ElementsAs(ctx, &modelLocalizations, false)...)
+
+	var categoryIds []int64
+	diags.Append(data.Categories.ElementsAs(ctx, &categoryIds, false)...)
+
+	localizations := map[string]remsclient.CatalogueItemLocalization{}
+
+	for lang, l := range modelLocalizations {
+		localization := remsclient.NewCatalogueItemLocalization(l.Title.ValueString())
+		if !l.Infourl.IsNull() {
+			localization.SetInfourl(l.Infourl.ValueString())
+		}
+		localizations[lang] = *localization
+	}
+
+	categories := make([]remsclient.CategoryId, 0, len(categoryIds))
+
+	for _, c := range categoryIds {
+		categories = append(categories, *remsclient.NewCategoryId(c))
+	}
+
+	return localizations, categories, diags
 }
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func catalogueItemModel(t *testing.T, id types.Int64, categories []int64) CatalogueItemResourceModel {
+	localizations, diags := types.MapValueFrom(context.Background(), catalogueItemLocalizationType, map[string]CatalogueItemLocalizationResourceModel{
+		"en": {Title: types.StringValue("Melanoma cohort"), Infourl: types.StringValue("https://example.org/melanoma")},
+		"fi": {Title: types.StringValue("Melanoomakohortti"), Infourl: types.StringNull()},
+	})
+	requireNoErrors(t, diags)
+
+	model := CatalogueItemResourceModel{
+		Id:             id,
+		OrganizationId: types.StringValue("umccr"),
+		ResourceId:     types.Int64Value(12),
+		WorkflowId:     types.Int64Value(4),
+		FormId:         types.Int64Null(),
+		Localizations:  localizations,
+		Categories:     types.ListNull(types.Int64Type),
+	}
+
+	if categories != nil {
+		var d diag.Diagnostics
+		model.Categories, d = types.ListValueFrom(context.Background(), types.Int64Type, categories)
+		requireNoErrors(t, d)
+	}
+
+	return model
+}
+
+func catalogueItemJson(archived string, expired string) string {
+	return `{
+	"id": 21,
+	"resource-id": 12,
+	"resid": "urn:example:dataset:1",
+	"wfid": 4,
+	"formid": null,
+	"organization": {"organization/id": "umccr"},
+	"localizations": {
+		"en": {"id": 21, "langcode": "en", "title": "Melanoma cohort", "infourl": "https://example.org/melanoma"},
+		"fi": {"id": 21, "langcode": "fi", "title": "Melanoomakohortti", "infourl": null}
+	},
+	"categories": [{"category/id": 2, "category/title": {"en": "Cancer"}}],
+	"start": "2024-01-01T00:00:00Z",
+	"end": null,
+	"enabled": true,
+	"archived": ` + archived + `,
+	"expired": ` + expired + `
+}`
+}
+
+func TestCatalogueItemResourceCreate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"POST /api/catalogue-items/create": `{"success": true, "id": 21}`,
+	})
+	r := newTestResource(t, NewCatalogueItemResource(), f)
+
+	state, diags := r.Create(t, catalogueItemModel(t, types.Int64Unknown(), []int64{2}))
+	requireNoErrors(t, diags)
+
+	var created CatalogueItemResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &created))
+	assert.Equal(t, types.Int64Value(21), created.Id)
+
+	body := f.Request(t, "POST /api/catalogue-items/create").Body
+	assert.Equal(t, float64(12), body["resid"])
+	assert.Equal(t, float64(4), body["wfid"])
+	assert.NotContains(t, body, "form")
+	assert.Equal(t, []interface{}{map[string]interface{}{"category/id": float64(2)}}, body["categories"])
+	assert.Equal(t, map[string]interface{}{
+		"en": map[string]interface{}{"title": "Melanoma cohort", "infourl": "https://example.org/melanoma"},
+		"fi": map[string]interface{}{"title": "Melanoomakohortti"},
+	}, body["localizations"])
+}
+
+func TestCatalogueItemResourceRead(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/catalogue-items/21": catalogueItemJson("false", "false"),
+	})
+	r := newTestResource(t, NewCatalogueItemResource(), f)
+
+	state, diags := r.Read(t, catalogueItemModel(t, types.Int64Value(21), nil))
+	requireNoErrors(t, diags)
+
+	var read CatalogueItemResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+	assert.Equal(t, catalogueItemModel(t, types.Int64Value(21), []int64{2}), read)
+}
+
+func TestCatalogueItemResourceReadGone(t *testing.T) {
+	for name, item := range map[string]string{
+		"archived": catalogueItemJson("true", "false"),
+		"expired":  catalogueItemJson("false", "true"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := newTestResource(t, NewCatalogueItemResource(), newFakeRems(t, map[string]string{"GET /api/catalogue-items/21": item}))
+
+			state, diags := r.Read(t, catalogueItemModel(t, types.Int64Value(21), nil))
+			requireNoErrors(t, diags)
+			assert.True(t, state.Raw.IsNull(), "expected the catalogue item to be removed from state")
+		})
+	}
+}
+
+func TestCatalogueItemResourceUpdate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/catalogue-items/edit": `{"success": true}`,
+	})
+	r := newTestResource(t, NewCatalogueItemResource(), f)
+
+	_, diags := r.Update(t, catalogueItemModel(t, types.Int64Value(21), nil), catalogueItemModel(t, types.Int64Value(21), []int64{2, 3}))
+	requireNoErrors(t, diags)
+
+	body := f.Request(t, "PUT /api/catalogue-items/edit").Body
+	assert.Equal(t, float64(21), body["id"])
+	assert.Equal(t, map[string]interface{}{"organization/id": "umccr"}, body["organization"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"category/id": float64(2)}, map[string]interface{}{"category/id": float64(3)}}, body["categories"])
+}
+
+func TestCatalogueItemResourceDelete(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/catalogue-items/archived": `{"success": true}`,
+	})
+	r := newTestResource(t, NewCatalogueItemResource(), f)
+
+	requireNoErrors(t, r.Delete(t, catalogueItemModel(t, types.Int64Value(21), nil)))
+
+	assert.Equal(t, map[string]interface{}{"id": float64(21), "archived": true}, f.Request(t, "PUT /api/catalogue-items/archived").Body)
+}
@@ -8,19 +8,20 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CategoryResource{}
 var _ resource.ResourceWithImportState = &CategoryResource{}
+var _ resource.ResourceWithIdentity = &CategoryResource{}
 
 func NewCategoryResource() resource.Resource {
 	return &CategoryResource{}
@@ -28,14 +29,16 @@ func NewCategoryResource() resource.Resource {
 
 // CategoryResource defines the resource implementation.
 type CategoryResource struct {
-	client *http.Client
+	client *remsclient.APIClient
 }
 
 // CategoryResourceModel describes the resource data model.
 type CategoryResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	Id           types.Int64 `tfsdk:"id"`
+	Title        types.Map   `tfsdk:"title"`
+	Description  types.Map   `tfsdk:"description"`
+	DisplayOrder types.Int64 `tfsdk:"display_order"`
+	Children     types.List  `tfsdk:"children"`
 }
 
 func (r *CategoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,42 +48,55 @@ func (r *CategoryResource) Metadata(ctx context.Context, req resource.MetadataRe
 func (r *CategoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Category resource",
+		MarkdownDescription: "Category of the catalogue",
 
 		Attributes: map[string]schema.Attribute{
-			"configurable_attribute": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Category internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.MapAttribute{
+				MarkdownDescription: "Category title keyed by language",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"description": schema.MapAttribute{
+				MarkdownDescription: "Category description keyed by language",
+				ElementType:         types.StringType,
 				Optional:            true,
 			},
-			"defaulted": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute with default value",
+			"display_order": schema.Int64Attribute{
+				MarkdownDescription: "Position of the category amongst its siblings",
 				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString("example value when not configured"),
 			},
-			"id": schema.StringAttribute{
-				Computed:            true,
-				MarkdownDescription: "Example identifier",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+			"children": schema.ListAttribute{
+				MarkdownDescription: "Ids of the categories nested under this one",
+				ElementType:         types.Int64Type,
+				Optional:            true,
 			},
 		},
 	}
 }
 
+func (r *CategoryResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema
+}
+
 func (r *CategoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*remsclient.APIClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -99,24 +115,50 @@ func (r *CategoryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	title, description, children, diags := data.categoryValues(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categoryConfig := remsclient.NewCreateCategoryCommand(title)
+	categoryConfig.CategoryDescription = description
+	categoryConfig.CategoryDisplayOrder = data.DisplayOrder.ValueInt64Pointer()
+	categoryConfig.CategoryChildren = children
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	createResult, createResponse, createErr := r.client.CategoriesAPI.
+		ApiCategoriesCreatePost(context.Background()).
+		CreateCategoryCommand(*categoryConfig).
+		Execute()
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create category",
+			fmt.Sprintf("Could not create category: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	// the generated client leaves this response untyped
+	success, _ := createResult["success"].(bool)
+	id, hasId := createResult["category/id"].(float64)
+
+	if !success || !hasId {
+		resp.Diagnostics.AddError(
+			"Failure to create category",
+			fmt.Sprintf("Could not create category: %v", createResult["errors"]),
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(int64(id))
+
+	tflog.Trace(ctx, "created a category")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *CategoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -129,16 +171,55 @@ func (r *CategoryResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	category, categoryResponse, categoryErr := r.client.CategoriesAPI.
+		ApiCategoriesCategoryIdGet(context.Background(), data.Id.ValueInt64()).
+		Execute()
+
+	if categoryResponse != nil && categoryResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if categoryErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read category",
+			fmt.Sprintf("Could not read category %d: %s %v", data.Id.ValueInt64(), categoryErr.Error(), categoryResponse),
+		)
+		return
+	}
+
+	var d diag.Diagnostics
+
+	data.Title, d = types.MapValueFrom(ctx, types.StringType, category.CategoryTitle)
+	resp.Diagnostics.Append(d...)
+
+	if category.CategoryDescription != nil {
+		data.Description, d = types.MapValueFrom(ctx, types.StringType, *category.CategoryDescription)
+		resp.Diagnostics.Append(d...)
+	} else {
+		data.Description = types.MapNull(types.StringType)
+	}
+
+	data.DisplayOrder = types.Int64PointerValue(category.CategoryDisplayOrder)
+
+	// keep an unset list unset rather than flipping it to empty
+	if len(category.CategoryChildren) > 0 || !data.Children.IsNull() {
+		children := make([]int64, 0, len(category.CategoryChildren))
+		for _, c := range category.CategoryChildren {
+			children = append(children, c.CategoryId)
+		}
+
+		data.Children, d = types.ListValueFrom(ctx, types.Int64Type, children)
+		resp.Diagnostics.Append(d...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *CategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -151,16 +232,42 @@ func (r *CategoryResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	title, description, children, diags := data.categoryValues(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categoryConfig := remsclient.NewUpdateCategoryCommand(title, data.Id.ValueInt64())
+	categoryConfig.CategoryDescription = description
+	categoryConfig.CategoryDisplayOrder = data.DisplayOrder.ValueInt64Pointer()
+	categoryConfig.CategoryChildren = children
+
+	editResult, editResponse, editErr := r.client.CategoriesAPI.
+		ApiCategoriesEditPut(context.Background()).
+		UpdateCategoryCommand(*categoryConfig).
+		Execute()
+
+	if editErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to edit category",
+			fmt.Sprintf("Could not edit category %d: %s %v", data.Id.ValueInt64(), editErr.Error(), editResponse),
+		)
+		return
+	}
+
+	if !editResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to edit category",
+			fmt.Sprintf("Could not edit category %d: %v", data.Id.ValueInt64(), editResult.GetErrors()),
+		)
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *CategoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -173,15 +280,59 @@ func (r *CategoryResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	// unlike most REMS objects categories can really be deleted
+	deleteResult, deleteResponse, deleteErr := r.client.CategoriesAPI.
+		ApiCategoriesDeletePost(context.Background()).
+		DeleteCategoryCommand(*remsclient.NewDeleteCategoryCommand(data.Id.ValueInt64())).
+		Execute()
+
+	if deleteErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to delete category",
+			fmt.Sprintf("Could not delete category %d: %s %v", data.Id.ValueInt64(), deleteErr.Error(), deleteResponse),
+		)
+		return
+	}
+
+	if !deleteResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to delete category",
+			fmt.Sprintf("Could not delete category %d: %v", data.Id.ValueInt64(), deleteResult.GetErrors()),
+		)
+	}
 }
 
 func (r *CategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateInt64Id(ctx, identityTypeCategory, req, resp)
+}
+
+func (data *CategoryResourceModel) identity() objectIdentityModel {
+	return newObjectIdentity(identityTypeCategory, data.Id)
+}
+
+// categoryValues converts the model into the shapes shared by the create and update commands.
+func (data *CategoryResourceModel) categoryValues(ctx context.Context) (map[string]string, *map[string]string, []remsclient.CategoryId, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	title := map[string]string{}
+	diags.Append(data.Title.ElementsAs(ctx, &title, false)...)
+
+	var description *map[string]string
+
+	if !data.Description.IsNull() {
+		d := map[string]string{}
+		diags.Append(data.Description.ElementsAs(ctx, &d, false)...)
+		description = &d
+	}
+
+	var childIds []int64
+	diags.Append(data.Children.ElementsAs(ctx, &childIds, false)...)
+
+	children := make([]remsclient.CategoryId, 0, len(childIds))
+
+	for _, c := range childIds {
+		children = append(children, *remsclient.NewCategoryId(c))
+	}
+
+	return title, description, children, diags
 }
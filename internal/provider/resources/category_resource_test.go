@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func categoryModel(t *testing.T, id types.Int64, children []int64) CategoryResourceModel {
+	title, diags := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"en": "Cancer"})
+	requireNoErrors(t, diags)
+
+	model := CategoryResourceModel{
+		Id:           id,
+		Title:        title,
+		Description:  types.MapNull(types.StringType),
+		DisplayOrder: types.Int64Value(1),
+		Children:     types.ListNull(types.Int64Type),
+	}
+
+	if children != nil {
+		var d diag.Diagnostics
+		model.Children, d = types.ListValueFrom(context.Background(), types.Int64Type, children)
+		requireNoErrors(t, d)
+	}
+
+	return model
+}
+
+func TestCategoryResourceCreate(t *testing.T) {
+	tests := map[string]struct {
+		response string
+		fails    bool
+	}{
+		"created": {response: `{"success": true, "category/id": 2}`},
+		"failed":  {response: `{"success": false, "errors": [{"type": "t.actions.errors/duplicate"}]}`, fails: true},
+		"no id":   {response: `{"success": true}`, fails: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := newFakeRems(t, map[string]string{
+				"POST /api/categories/create": test.response,
+			})
+			r := newTestResource(t, NewCategoryResource(), f)
+
+			state, diags := r.Create(t, categoryModel(t, types.Int64Unknown(), []int64{3}))
+
+			if test.fails {
+				require.True(t, diags.HasError())
+				assert.Equal(t, "Failure to create category", diags[0].Summary())
+				return
+			}
+
+			requireNoErrors(t, diags)
+
+			var created CategoryResourceModel
+			requireNoErrors(t, state.Get(context.Background(), &created))
+			assert.Equal(t, types.Int64Value(2), created.Id)
+
+			assert.Equal(t, map[string]interface{}{
+				"category/title":         map[string]interface{}{"en": "Cancer"},
+				"category/display-order": float64(1),
+				"category/children":      []interface{}{map[string]interface{}{"category/id": float64(3)}},
+			}, f.Request(t, "POST /api/categories/create").Body)
+		})
+	}
+}
+
+func TestCategoryResourceRead(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/categories/2": `{
+			"category/id": 2,
+			"category/title": {"en": "Cancer"},
+			"category/display-order": 1,
+			"category/children": [{"category/id": 3, "category/title": {"en": "Melanoma"}}]
+		}`,
+	})
+	r := newTestResource(t, NewCategoryResource(), f)
+
+	state, diags := r.Read(t, categoryModel(t, types.Int64Value(2), nil))
+	requireNoErrors(t, diags)
+
+	var read CategoryResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+	assert.Equal(t, categoryModel(t, types.Int64Value(2), []int64{3}), read)
+}
+
+func TestCategoryResourceUpdate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/categories/edit": `{"success": true}`,
+	})
+	r := newTestResource(t, NewCategoryResource(), f)
+
+	plan := categoryModel(t, types.Int64Value(2), nil)
+	plan.DisplayOrder = types.Int64Null()
+
+	_, diags := r.Update(t, categoryModel(t, types.Int64Value(2), nil), plan)
+	requireNoErrors(t, diags)
+
+	// an edit replaces the children, so no children are sent as an empty list
+	assert.Equal(t, map[string]interface{}{
+		"category/id":       float64(2),
+		"category/title":    map[string]interface{}{"en": "Cancer"},
+		"category/children": []interface{}{},
+	}, f.Request(t, "PUT /api/categories/edit").Body)
+}
+
+func TestCategoryResourceDelete(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"POST /api/categories/delete": `{"success": true}`,
+	})
+	r := newTestResource(t, NewCategoryResource(), f)
+
+	requireNoErrors(t, r.Delete(t, categoryModel(t, types.Int64Value(2), nil)))
+
+	assert.Equal(t, map[string]interface{}{"category/id": float64(2)}, f.Request(t, "POST /api/categories/delete").Body)
+}
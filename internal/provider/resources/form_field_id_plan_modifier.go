@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// generatedFieldIdPattern matches the field ids that REMS generates itself.
+var generatedFieldIdPattern = regexp.MustCompile(`^fld(\d+)$`)
+
+// formFieldIdsPlanModifier keeps the computed ids of form fields stable across
+// updates.
+//
+// REMS keys application answers by field id, so a field that is merely moved
+// (or has its wording tweaked) must keep its id or answers in live applications
+// are orphaned. Fields without a configured id are first matched to a field in
+// the prior state with identical content, then to a field of the same type at
+// the same position. Anything left over is a new field and is given the next
+// free id in the REMS style so that the plan is fully known.
+type formFieldIdsPlanModifier struct{}
+
+func (m formFieldIdsPlanModifier) Description(_ context.Context) string {
+	return "Preserves the ids of form fields that are reordered or edited in place."
+}
+
+func (m formFieldIdsPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m formFieldIdsPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// nothing to preserve on create, and nothing we can do until the fields are known
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var planFields []FormFieldResourceModel
+	var stateFields []FormFieldResourceModel
+
+	resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &planFields, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &stateFields, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ids configured explicitly are never up for grabs
+	usedIds := map[string]bool{}
+
+	for _, f := range planFields {
+		if !f.Id.IsUnknown() && !f.Id.IsNull() {
+			usedIds[f.Id.ValueString()] = true
+		}
+	}
+
+	// new ids continue on from the highest one ever seen, so that the id of a
+	// removed field is not handed to an unrelated new one
+	highest := 0
+
+	for _, fields := range [][]FormFieldResourceModel{planFields, stateFields} {
+		for _, f := range fields {
+			if match := generatedFieldIdPattern.FindStringSubmatch(f.Id.ValueString()); match != nil {
+				if n, err := strconv.Atoi(match[1]); err == nil && n > highest {
+					highest = n
+				}
+			}
+		}
+	}
+
+	claimed := make([]bool, len(stateFields))
+
+	claim := func(i int, j int) {
+		planFields[i].Id = stateFields[j].Id
+		usedIds[stateFields[j].Id.ValueString()] = true
+		claimed[j] = true
+	}
+
+	available := func(j int) bool {
+		return !claimed[j] && !stateFields[j].Id.IsNull() && !usedIds[stateFields[j].Id.ValueString()]
+	}
+
+	// first pass - identical content anywhere in the prior state
+	for i := range planFields {
+		if !planFields[i].Id.IsUnknown() {
+			continue
+		}
+		for j := range stateFields {
+			if available(j) && sameFormFieldContent(planFields[i], stateFields[j]) {
+				claim(i, j)
+				break
+			}
+		}
+	}
+
+	// second pass - a field of the same type at the same position has been edited in place
+	for i := range planFields {
+		if !planFields[i].Id.IsUnknown() || i >= len(stateFields) {
+			continue
+		}
+		if available(i) && planFields[i].Type.Equal(stateFields[i].Type) {
+			claim(i, i)
+		}
+	}
+
+	// whatever is left is new
+	for i := range planFields {
+		if !planFields[i].Id.IsUnknown() {
+			continue
+		}
+		for {
+			highest++
+			id := fmt.Sprintf("fld%d", highest)
+			if !usedIds[id] {
+				planFields[i].Id = types.StringValue(id)
+				usedIds[id] = true
+				break
+			}
+		}
+	}
+
+	planValue, diags := types.ListValueFrom(ctx, req.PlanValue.ElementType(ctx), planFields)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.PlanValue = planValue
+}
+
+// sameFormFieldContent compares everything about two fields except their id.
+func sameFormFieldContent(a FormFieldResourceModel, b FormFieldResourceModel) bool {
+	return a.Type.Equal(b.Type) &&
+		a.Title.Equal(b.Title) &&
+		a.Info.Equal(b.Info) &&
+		a.Placeholder.Equal(b.Placeholder) &&
+		a.Optional.Equal(b.Optional) &&
+		a.MaxLength.Equal(b.MaxLength) &&
+		a.Options.Equal(b.Options) &&
+		a.Columns.Equal(b.Columns) &&
+		a.Privacy.Equal(b.Privacy) &&
+		a.Visibility.Equal(b.Visibility)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
+)
+
+var formFieldType = types.ObjectType{AttrTypes: form_fields.AttributeTypes}
+
+// formField is a field with just an id, a type and an English title.
+func formField(id types.String, fieldType string, title string) FormFieldResourceModel {
+	optionType := types.ObjectType{AttrTypes: form_fields.OptionAttributeTypes}
+
+	return FormFieldResourceModel{
+		Id:          id,
+		Type:        types.StringValue(fieldType),
+		Title:       types.MapValueMust(types.StringType, map[string]attr.Value{"en": types.StringValue(title)}),
+		Info:        types.MapNull(types.StringType),
+		Placeholder: types.MapNull(types.StringType),
+		Optional:    types.BoolValue(false),
+		MaxLength:   types.Int64Null(),
+		Options:     types.ListNull(optionType),
+		Columns:     types.ListNull(optionType),
+		Privacy:     types.StringNull(),
+		Visibility:  types.ObjectNull(form_fields.VisibilityAttributeTypes),
+	}
+}
+
+func formFieldsValue(t *testing.T, fields ...FormFieldResourceModel) types.List {
+	t.Helper()
+
+	list, diags := types.ListValueFrom(context.Background(), formFieldType, fields)
+	requireNoErrors(t, diags)
+
+	return list
+}
+
+func formFieldIds(t *testing.T, list types.List) []string {
+	t.Helper()
+
+	var fields []FormFieldResourceModel
+	requireNoErrors(t, list.ElementsAs(context.Background(), &fields, false))
+
+	ids := []string{}
+	for _, f := range fields {
+		if f.Id.IsUnknown() {
+			ids = append(ids, "(unknown)")
+		} else {
+			ids = append(ids, f.Id.ValueString())
+		}
+	}
+
+	return ids
+}
+
+func TestFormFieldIdsPlanModifier(t *testing.T) {
+	unknown := types.StringUnknown()
+	id := types.StringValue
+
+	tests := map[string]struct {
+		state    types.List
+		plan     types.List
+		expected []string
+	}{
+		"create leaves ids to REMS": {
+			state:    types.ListNull(formFieldType),
+			plan:     formFieldsValue(t, formField(unknown, "text", "Name")),
+			expected: []string{"(unknown)"},
+		},
+		"unchanged": {
+			state:    formFieldsValue(t, formField(id("fld1"), "text", "Name"), formField(id("fld2"), "email", "Email")),
+			plan:     formFieldsValue(t, formField(unknown, "text", "Name"), formField(unknown, "email", "Email")),
+			expected: []string{"fld1", "fld2"},
+		},
+		"reorder keeps ids with their content": {
+			state:    formFieldsValue(t, formField(id("fld1"), "text", "Name"), formField(id("fld2"), "text", "Email")),
+			plan:     formFieldsValue(t, formField(unknown, "text", "Email"), formField(unknown, "text", "Name")),
+			expected: []string{"fld2", "fld1"},
+		},
+		"edit in place keeps the id": {
+			state:    formFieldsValue(t, formField(id("fld1"), "text", "Name"), formField(id("fld2"), "email", "Email")),
+			plan:     formFieldsValue(t, formField(unknown, "text", "Full name"), formField(unknown, "email", "Email")),
+			expected: []string{"fld1", "fld2"},
+		},
+		"change of type is a new field": {
+			state:    formFieldsValue(t, formField(id("fld1"), "text", "Name")),
+			plan:     formFieldsValue(t, formField(unknown, "texta", "Name")),
+			expected: []string{"fld2"},
+		},
+		"removal then add does not reuse the removed id": {
+			state: formFieldsValue(t,
+				formField(id("fld1"), "text", "Name"),
+				formField(id("fld2"), "email", "Email"),
+				formField(id("fld3"), "text", "Address"),
+			),
+			plan: formFieldsValue(t,
+				formField(unknown, "text", "Name"),
+				formField(unknown, "text", "Address"),
+				formField(unknown, "email", "Work email"),
+			),
+			expected: []string{"fld1", "fld3", "fld4"},
+		},
+		"explicit ids are kept": {
+			state:    formFieldsValue(t, formField(id("fld1"), "text", "Name")),
+			plan:     formFieldsValue(t, formField(id("name"), "text", "Name"), formField(unknown, "email", "Email")),
+			expected: []string{"name", "fld2"},
+		},
+		"explicit ids colliding with generated ones": {
+			state: formFieldsValue(t,
+				formField(id("fld1"), "text", "Name"),
+				formField(id("fld2"), "email", "Email"),
+			),
+			plan: formFieldsValue(t,
+				formField(id("fld1"), "header", "Applicant"),
+				formField(unknown, "text", "Name"),
+				formField(id("fld4"), "email", "Email"),
+				formField(unknown, "date", "Start"),
+			),
+			expected: []string{"fld1", "fld5", "fld4", "fld6"},
+		},
+		"unknown fields are left alone": {
+			state:    formFieldsValue(t, formField(id("fld1"), "text", "Name")),
+			plan:     types.ListUnknown(formFieldType),
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.ListRequest{StateValue: test.state, PlanValue: test.plan}
+			resp := planmodifier.ListResponse{PlanValue: test.plan}
+
+			formFieldIdsPlanModifier{}.PlanModifyList(context.Background(), req, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			if test.expected == nil {
+				assert.Equal(t, test.plan, resp.PlanValue)
+				return
+			}
+
+			assert.Equal(t, test.expected, formFieldIds(t, resp.PlanValue))
+		})
+	}
+}
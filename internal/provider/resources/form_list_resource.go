@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &FormListResource{}
+var _ list.ListResourceWithConfigure = &FormListResource{}
+
+func NewFormListResource() list.ListResource {
+	return &FormListResource{}
+}
+
+// FormListResource lists the forms of a REMS instance.
+type FormListResource struct {
+	client *remsclient.APIClient
+}
+
+func (r *FormListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_form"
+}
+
+func (r *FormListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists forms",
+		Attributes:          listFilterAttributes("forms"),
+	}
+}
+
+func (r *FormListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = listClient(req, resp)
+}
+
+func (r *FormListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter listFilterModel
+
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	forms, formsResponse, formsErr := r.client.FormsAPI.
+		ApiFormsGet(ctx).
+		Archived(filter.Archived.ValueBool()).
+		Disabled(filter.Disabled.ValueBool()).
+		Execute()
+
+	if formsErr != nil {
+		listHttpError(stream, "forms", formsErr, formsResponse)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, overview := range forms {
+			if !filter.includes(overview.Organization.OrganizationId) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+
+			result.DisplayName = overview.FormInternalName
+
+			identity := newOrganizationObjectIdentity(identityTypeForm, types.Int64Value(overview.FormId), types.StringValue(overview.Organization.OrganizationId))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				// the listing leaves out the fields
+				form, formResponse, formErr := r.client.FormsAPI.ApiFormsFormIdGet(ctx, overview.FormId).Execute()
+
+				if formErr != nil {
+					result.Diagnostics.AddError(
+						"Failure to read form",
+						fmt.Sprintf("Could not read form %d: %s %v", overview.FormId, formErr.Error(), formResponse),
+					)
+				} else {
+					var data FormResourceModel
+					result.Diagnostics.Append(data.fromForm(ctx, form)...)
+					result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
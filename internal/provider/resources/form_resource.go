@@ -6,20 +6,29 @@ package resources
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
 	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FormResource{}
 var _ resource.ResourceWithImportState = &FormResource{}
+var _ resource.ResourceWithValidateConfig = &FormResource{}
+var _ resource.ResourceWithIdentity = &FormResource{}
+var _ resource.ResourceWithUpgradeState = &FormResource{}
 
 func NewFormResource() resource.Resource {
 	return &FormResource{}
@@ -101,32 +110,107 @@ type FormFieldResourceModel struct {
 	Id          types.String `tfsdk:"id"`
 	Type        types.String `tfsdk:"type"`
 	Title       types.Map    `tfsdk:"title"`
-	Info        types.String `tfsdk:"info"`
-	Placeholder types.String `tfsdk:"placeholder"`
+	Info        types.Map    `tfsdk:"info"`
+	Placeholder types.Map    `tfsdk:"placeholder"`
 	Optional    types.Bool   `tfsdk:"optional"`
+	MaxLength   types.Int64  `tfsdk:"max_length"`
+	Options     types.List   `tfsdk:"options"`
+	Columns     types.List   `tfsdk:"columns"`
+	Privacy     types.String `tfsdk:"privacy"`
+	Visibility  types.Object `tfsdk:"visibility"`
+}
+
+// FormFieldVisibilityResourceModel describes when a field is shown to the applicant.
+type FormFieldVisibilityResourceModel struct {
+	Type    types.String `tfsdk:"type"`
+	FieldId types.String `tfsdk:"field_id"`
+	Values  types.List   `tfsdk:"values"`
+}
+
+// FormFieldOptionResourceModel is used for both the options of option/multiselect
+// fields and the columns of table fields - REMS gives them the same shape.
+type FormFieldOptionResourceModel struct {
+	Key   types.String `tfsdk:"key"`
+	Label types.Map    `tfsdk:"label"`
+}
+
+var fieldOptionSchema = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Required: true,
+		},
+		"label": schema.MapAttribute{
+			ElementType: types.StringType,
+			Required:    true,
+		},
+	},
 }
 
 var fieldSchema = schema.NestedAttributeObject{
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Optional: true,
+			MarkdownDescription: "Field identifier. Generated by REMS (`fld1`, `fld2`...) when not given, and kept stable when fields are reordered",
+			Optional:            true,
+			Computed:            true,
 		},
 		"type": schema.StringAttribute{
-			Required: true,
+			MarkdownDescription: "Field type, one of `" + strings.Join(form_fields.Types, "`, `") + "`",
+			Required:            true,
 		},
 		"title": schema.MapAttribute{
 			ElementType: types.StringType,
 			Optional:    true,
 		},
-		"info": schema.StringAttribute{
-			Optional: true,
+		"info": schema.MapAttribute{
+			MarkdownDescription: "Localized help text shown with the field",
+			ElementType:         types.StringType,
+			Optional:            true,
 		},
-		"placeholder": schema.StringAttribute{
-			Optional: true,
+		"placeholder": schema.MapAttribute{
+			MarkdownDescription: "Localized placeholder shown in an empty field",
+			ElementType:         types.StringType,
+			Optional:            true,
 		},
 		"optional": schema.BoolAttribute{
 			Optional: true,
 		},
+		"max_length": schema.Int64Attribute{
+			MarkdownDescription: "Maximum answer length for `text`, `texta` and `description` fields",
+			Optional:            true,
+		},
+		"options": schema.ListNestedAttribute{
+			MarkdownDescription: "Choices for `option` and `multiselect` fields",
+			NestedObject:        fieldOptionSchema,
+			Optional:            true,
+		},
+		"columns": schema.ListNestedAttribute{
+			MarkdownDescription: "Columns for `table` fields",
+			NestedObject:        fieldOptionSchema,
+			Optional:            true,
+		},
+		"privacy": schema.StringAttribute{
+			MarkdownDescription: "Whether the answer is shown to reviewers, `public` or `private`",
+			Optional:            true,
+		},
+		"visibility": schema.SingleNestedAttribute{
+			MarkdownDescription: "When the field is shown to the applicant",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					MarkdownDescription: "`always` or `only-if`",
+					Required:            true,
+				},
+				"field_id": schema.StringAttribute{
+					MarkdownDescription: "For `only-if`, the `option` or `multiselect` field the visibility depends on",
+					Optional:            true,
+				},
+				"values": schema.ListAttribute{
+					MarkdownDescription: "For `only-if`, the option keys of that field which make this field visible",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+			},
+		},
 	},
 }
 
@@ -141,6 +225,7 @@ type FormResourceModel struct {
 func (r *FormResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Form",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -161,11 +246,332 @@ func (r *FormResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"fields": schema.ListNestedAttribute{
 				NestedObject: fieldSchema,
 				Required:     true,
+				PlanModifiers: []planmodifier.List{
+					formFieldIdsPlanModifier{},
+				},
 			},
 		},
 	}
 }
 
+func (r *FormResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var resourceModel FormResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &resourceModel)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the fields may come from a for expression over values that are not yet known
+	if resourceModel.Fields.IsNull() || resourceModel.Fields.IsUnknown() {
+		return
+	}
+
+	modelFields := make([]FormFieldResourceModel, 0, len(resourceModel.Fields.Elements()))
+	resp.Diagnostics.Append(resourceModel.Fields.ElementsAs(ctx, &modelFields, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fields := make([]form_fields.Field, len(modelFields))
+
+	for i, modelField := range modelFields {
+		field, fieldDiagnostics := formFieldForValidation(ctx, modelField)
+		resp.Diagnostics.Append(fieldDiagnostics...)
+		fields[i] = field
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, problem := range form_fields.ValidateFields(fields) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fields").AtListIndex(problem.Index).AtName(problem.Attribute),
+			problem.Summary,
+			problem.Detail,
+		)
+	}
+}
+
+// formFieldForValidation converts a field from the Terraform model into the shape
+// the form field rules work on, leaving anything not yet known unset.
+func formFieldForValidation(ctx context.Context, m FormFieldResourceModel) (form_fields.Field, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	field := form_fields.Field{
+		HasInfo:        !m.Info.IsNull(),
+		HasPlaceholder: !m.Placeholder.IsNull(),
+		HasOptions:     !m.Options.IsNull(),
+		HasColumns:     !m.Columns.IsNull(),
+	}
+
+	if !m.Id.IsNull() && !m.Id.IsUnknown() {
+		field.Id = m.Id.ValueStringPointer()
+	}
+	if !m.Type.IsNull() && !m.Type.IsUnknown() {
+		field.Type = m.Type.ValueStringPointer()
+	}
+	if !m.Optional.IsNull() && !m.Optional.IsUnknown() {
+		field.Optional = m.Optional.ValueBoolPointer()
+	}
+	if !m.MaxLength.IsNull() && !m.MaxLength.IsUnknown() {
+		field.MaxLength = m.MaxLength.ValueInt64Pointer()
+	}
+	if !m.Privacy.IsNull() && !m.Privacy.IsUnknown() {
+		field.Privacy = m.Privacy.ValueStringPointer()
+	}
+	if !m.Title.IsNull() && !m.Title.IsUnknown() && !hasUnknownElement(m.Title.Elements()) {
+		field.Title = map[string]string{}
+		diags.Append(m.Title.ElementsAs(ctx, &field.Title, false)...)
+	}
+	if !m.Visibility.IsNull() && !m.Visibility.IsUnknown() {
+		var visibility FormFieldVisibilityResourceModel
+		diags.Append(m.Visibility.As(ctx, &visibility, basetypes.ObjectAsOptions{})...)
+
+		field.Visibility = &form_fields.Visibility{}
+		if !visibility.Type.IsUnknown() {
+			field.Visibility.Type = visibility.Type.ValueStringPointer()
+		}
+		if !visibility.FieldId.IsUnknown() {
+			field.Visibility.FieldId = visibility.FieldId.ValueStringPointer()
+		}
+		if visibility.Values.IsNull() {
+			field.Visibility.Values = []string{}
+		} else if !visibility.Values.IsUnknown() && !hasUnknownListElement(visibility.Values) {
+			field.Visibility.Values = []string{}
+			diags.Append(visibility.Values.ElementsAs(ctx, &field.Visibility.Values, false)...)
+		}
+	}
+
+	options, optionsDiagnostics := formFieldOptions(ctx, m.Options)
+	diags.Append(optionsDiagnostics...)
+	field.Options = options
+
+	columns, columnsDiagnostics := formFieldOptions(ctx, m.Columns)
+	diags.Append(columnsDiagnostics...)
+	field.Columns = columns
+
+	return field, diags
+}
+
+// formFieldOptions converts a list of options or columns into key/label pairs. It
+// returns nil unless every key and label is known, so that the rules skip the
+// element checks at plan time.
+func formFieldOptions(ctx context.Context, list types.List) ([]form_fields.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	modelOptions := make([]FormFieldOptionResourceModel, 0, len(list.Elements()))
+	diags.Append(list.ElementsAs(ctx, &modelOptions, false)...)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	options := make([]form_fields.Option, 0, len(modelOptions))
+
+	for _, o := range modelOptions {
+		if o.Key.IsUnknown() || o.Label.IsUnknown() || hasUnknownElement(o.Label.Elements()) {
+			return nil, diags
+		}
+		label := map[string]string{}
+		diags.Append(o.Label.ElementsAs(ctx, &label, false)...)
+		options = append(options, form_fields.Option{Key: o.Key.ValueString(), Label: label})
+	}
+
+	return options, diags
+}
+
+func hasUnknownListElement(list types.List) bool {
+	for _, e := range list.Elements() {
+		if e.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUnknownElement(elements map[string]attr.Value) bool {
+	for _, e := range elements {
+		if e.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+// newFormFields converts the fields of the Terraform model into the API model
+// shared by the create and edit form commands.
+func newFormFields(ctx context.Context, fields types.List) ([]remsclient.NewwFieldTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	modelFields := make([]FormFieldResourceModel, 0, len(fields.Elements()))
+	diags.Append(fields.ElementsAs(ctx, &modelFields, false)...)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	newFields := make([]remsclient.NewwFieldTemplate, 0, len(modelFields))
+
+	for _, modelFieldValue := range modelFields {
+		// every field is sent (REMS will complain about a missing title) so that
+		// the fields REMS returns line up with ours by position
+		titleMap := map[string]string{}
+
+		if !modelFieldValue.Title.IsNull() {
+			diags.Append(modelFieldValue.Title.ElementsAs(ctx, &titleMap, false)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+		}
+
+		newField := remsclient.NewNewFieldTemplate(
+			titleMap,
+			modelFieldValue.Type.ValueString(),
+			modelFieldValue.Optional.ValueBool())
+
+		if !modelFieldValue.Id.IsNull() && !modelFieldValue.Id.IsUnknown() {
+			newField.SetFieldId(modelFieldValue.Id.ValueString())
+		}
+
+		if !modelFieldValue.MaxLength.IsNull() {
+			newField.SetFieldMaxLength(modelFieldValue.MaxLength.ValueInt64())
+		}
+
+		if !modelFieldValue.Info.IsNull() {
+			infoMap := map[string]string{}
+			diags.Append(modelFieldValue.Info.ElementsAs(ctx, &infoMap, false)...)
+			newField.SetFieldInfoText(infoMap)
+		}
+
+		if !modelFieldValue.Placeholder.IsNull() {
+			placeholderMap := map[string]string{}
+			diags.Append(modelFieldValue.Placeholder.ElementsAs(ctx, &placeholderMap, false)...)
+			newField.SetFieldPlaceholder(placeholderMap)
+		}
+
+		if !modelFieldValue.Privacy.IsNull() {
+			newField.SetFieldPrivacy(modelFieldValue.Privacy.ValueString())
+		}
+
+		if !modelFieldValue.Visibility.IsNull() {
+			var visibility FormFieldVisibilityResourceModel
+			diags.Append(modelFieldValue.Visibility.As(ctx, &visibility, basetypes.ObjectAsOptions{})...)
+
+			newVisibility := remsclient.NewCreateFormCommandFieldsVisibility(visibility.Type.ValueString())
+
+			if !visibility.FieldId.IsNull() {
+				newVisibility.SetVisibilityField(*remsclient.NewCreateFormCommandFieldsVisibilityField(visibility.FieldId.ValueString()))
+			}
+
+			if !visibility.Values.IsNull() {
+				diags.Append(visibility.Values.ElementsAs(ctx, &newVisibility.VisibilityValues, false)...)
+			}
+
+			newField.SetFieldVisibility(*newVisibility)
+		}
+
+		options, optionsDiagnostics := formFieldOptions(ctx, modelFieldValue.Options)
+		diags.Append(optionsDiagnostics...)
+		for _, o := range options {
+			newField.FieldOptions = append(newField.FieldOptions, *remsclient.NewCreateFormCommandFieldsOptions(o.Key, o.Label))
+		}
+
+		columns, columnsDiagnostics := formFieldOptions(ctx, modelFieldValue.Columns)
+		diags.Append(columnsDiagnostics...)
+		for _, c := range columns {
+			newField.FieldColumns = append(newField.FieldColumns, *remsclient.NewCreateFormCommandFieldsColumns(c.Key, c.Label))
+		}
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		newFields = append(newFields, *newField)
+	}
+
+	return newFields, diags
+}
+
+// withRemoteFormFieldIds copies the field ids REMS holds onto our fields. REMS
+// keeps fields in the order they were sent so they are matched by position, but
+// only while both sides agree on the number of fields, their types and any ids
+// we already have. Otherwise the form has been changed outside of Terraform and
+// false is returned with the fields left alone.
+func withRemoteFormFieldIds(ctx context.Context, fields types.List, remoteFields []remsclient.FieldTemplate) (types.List, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	modelFields := make([]FormFieldResourceModel, 0, len(fields.Elements()))
+	diags.Append(fields.ElementsAs(ctx, &modelFields, false)...)
+
+	if diags.HasError() || len(modelFields) != len(remoteFields) {
+		return fields, false, diags
+	}
+
+	for i, f := range modelFields {
+		remote := remoteFields[i]
+
+		if f.Type.ValueString() != remote.FieldType {
+			return fields, false, diags
+		}
+
+		if !f.Id.IsNull() && !f.Id.IsUnknown() && f.Id.ValueString() != remote.FieldId {
+			return fields, false, diags
+		}
+
+		modelFields[i].Id = types.StringValue(remote.FieldId)
+	}
+
+	list, d := types.ListValueFrom(ctx, fields.ElementType(ctx), modelFields)
+	diags.Append(d...)
+
+	return list, true, diags
+}
+
+func (r *FormResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationObjectIdentitySchema
+}
+
+// fromForm sets the model from a form as returned by REMS. Fields we already
+// have only pick up their ids, so that attributes REMS fills in with defaults do
+// not show as changes. With no fields yet (after an import, or when listing), or
+// fields that no longer match those of REMS, all of them are taken from REMS.
+func (data *FormResourceModel) fromForm(ctx context.Context, form *remsclient.FormTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.Int64Value(form.FormId)
+	data.OrganizationId = types.StringValue(form.Organization.OrganizationId)
+	data.Title = types.StringPointerValue(form.FormTitle)
+
+	if !data.Fields.IsNull() {
+		fields, matched, d := withRemoteFormFieldIds(ctx, data.Fields, form.FormFields)
+		diags.Append(d...)
+
+		if matched {
+			data.Fields = fields
+			return diags
+		}
+	}
+
+	fields, d := form_fields.TemplatesValue(ctx, form.FormFields)
+	diags.Append(d...)
+
+	data.Fields = fields
+
+	return diags
+}
+
+func (data *FormResourceModel) identity() organizationObjectIdentityModel {
+	return newOrganizationObjectIdentity(identityTypeForm, data.Id, data.OrganizationId)
+}
+
 func (r *FormResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -196,13 +602,8 @@ func (r *FormResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Convert our resource model map with error checking
-	modelFields := make([]FormFieldResourceModel, len(resourceModel.Fields.Elements()))
-	modelFieldDiagnostics := resourceModel.Fields.ElementsAs(ctx, &modelFields, false)
-
-	if len(modelFieldDiagnostics) > 0 {
-		resp.Diagnostics.Append(modelFieldDiagnostics...)
-	}
+	newFields, newFieldsDiagnostics := newFormFields(ctx, resourceModel.Fields)
+	resp.Diagnostics.Append(newFieldsDiagnostics...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -221,30 +622,6 @@ func (r *FormResource) Create(ctx context.Context, req resource.CreateRequest, r
 		formConfig.SetFormTitle(resourceModel.Title.ValueString())
 	}
 
-	newFields := make([]remsclient.NewwFieldTemplate, 0)
-
-	for _, modelFieldValue := range modelFields {
-
-		if !modelFieldValue.Title.IsNull() && !modelFieldValue.Title.IsUnknown() {
-			var titleMap map[string]string
-			resp.Diagnostics.Append(modelFieldValue.Title.ElementsAs(ctx, &titleMap, false)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			newField := remsclient.NewNewFieldTemplate(
-				titleMap,
-				modelFieldValue.Type.ValueString(),
-				modelFieldValue.Optional.ValueBool())
-
-			if !modelFieldValue.Id.IsNull() {
-				newField.SetFieldId(modelFieldValue.Id.ValueString())
-			}
-
-			newFields = append(newFields, *newField)
-		}
-	}
-
 	formConfig.SetFormFields(newFields)
 
 	createResult, createResponse, createErr := r.client.FormsAPI.
@@ -272,12 +649,43 @@ func (r *FormResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	resourceModel.Id = types.Int64Value(createResult.GetId())
 
+	// REMS generates the ids of any fields we did not name, so fetch them back
+	form, formResponse, formErr := r.client.FormsAPI.
+		ApiFormsFormIdGet(context.Background(), resourceModel.Id.ValueInt64()).
+		Execute()
+
+	if formErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read form",
+			fmt.Sprintf("Could not read back created form %d: %s %v", resourceModel.Id.ValueInt64(), formErr.Error(), formResponse),
+		)
+		return
+	}
+
+	fields, matched, fieldsDiagnostics := withRemoteFormFieldIds(ctx, resourceModel.Fields, form.FormFields)
+	resp.Diagnostics.Append(fieldsDiagnostics...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !matched {
+		resp.Diagnostics.AddError(
+			"Failure to create form",
+			fmt.Sprintf("Created form %d does not hold the fields that were sent", resourceModel.Id.ValueInt64()),
+		)
+		return
+	}
+
+	resourceModel.Fields = fields
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created a resource")
 
 	// Save resourceModel into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &resourceModel)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, resourceModel.identity())...)
 }
 
 func (r *FormResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -290,16 +698,44 @@ func (r *FormResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	form, formResponse, formErr := r.client.FormsAPI.
+		ApiFormsFormIdGet(context.Background(), data.Id.ValueInt64()).
+		Execute()
+
+	if formResponse != nil && formResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if formErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read form",
+			fmt.Sprintf("Could not read form %d: %s %v", data.Id.ValueInt64(), formErr.Error(), formResponse),
+		)
+		return
+	}
+
+	// an archived form is as good as deleted
+	if form.Archived {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkImportedOrganization(ctx, data.OrganizationId, req.Identity, form.Organization.OrganizationId)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromForm(ctx, form)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *FormResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -312,16 +748,47 @@ func (r *FormResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	newFields, newFieldsDiagnostics := newFormFields(ctx, data.Fields)
+	resp.Diagnostics.Append(newFieldsDiagnostics...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgId := remsclient.NewOrganizationId(data.OrganizationId.ValueString())
+
+	formConfig := remsclient.NewEditFormCommand(*orgId, newFields, data.Id.ValueInt64())
+
+	if data.Title.IsNull() {
+		formConfig.SetFormTitleNil()
+	} else {
+		formConfig.SetFormTitle(data.Title.ValueString())
+	}
+
+	editResult, editResponse, editErr := r.client.FormsAPI.
+		ApiFormsEditPut(context.Background()).
+		EditFormCommand(*formConfig).
+		Execute()
+
+	if editErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to edit form",
+			fmt.Sprintf("Could not edit form %d: %s %v", data.Id.ValueInt64(), editErr.Error(), editResponse),
+		)
+		return
+	}
+
+	if !editResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to edit form",
+			fmt.Sprintf("Could not edit form %d: %v", data.Id.ValueInt64(), editResult.GetErrors()),
+		)
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *FormResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -334,17 +801,30 @@ func (r *FormResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	// REMS never deletes forms (applications refer to them) - the best we can do is archive
+	archiveResult, archiveResponse, archiveErr := r.client.FormsAPI.
+		ApiFormsArchivedPut(context.Background()).
+		ArchivedCommand(*remsclient.NewArchivedCommand(data.Id.ValueInt64(), true)).
+		Execute()
+
+	if archiveErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to archive form",
+			fmt.Sprintf("Could not archive form %d: %s %v", data.Id.ValueInt64(), archiveErr.Error(), archiveResponse),
+		)
+		return
+	}
+
+	if !archiveResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to archive form",
+			fmt.Sprintf("Could not archive form %d: %v", data.Id.ValueInt64(), archiveResult.GetErrors()),
+		)
+	}
 }
 
 func (r *FormResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateInt64Id(ctx, identityTypeForm, req, resp)
 }
 
 /*
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+func TestWithRemoteFormFieldIds(t *testing.T) {
+	unknown := types.StringUnknown()
+	id := types.StringValue
+	remote := func(fieldId string, fieldType string) remsclient.FieldTemplate {
+		return remsclient.FieldTemplate{FieldId: fieldId, FieldType: fieldType}
+	}
+
+	tests := map[string]struct {
+		fields   types.List
+		remote   []remsclient.FieldTemplate
+		matched  bool
+		expected []string
+	}{
+		"generated ids are picked up": {
+			fields:   formFieldsValue(t, formField(unknown, "text", "Name"), formField(id("email"), "email", "Email")),
+			remote:   []remsclient.FieldTemplate{remote("fld1", "text"), remote("email", "email")},
+			matched:  true,
+			expected: []string{"fld1", "email"},
+		},
+		"field added outside Terraform": {
+			fields:   formFieldsValue(t, formField(id("fld1"), "text", "Name")),
+			remote:   []remsclient.FieldTemplate{remote("fld1", "text"), remote("fld2", "email")},
+			expected: []string{"fld1"},
+		},
+		"field type changed outside Terraform": {
+			fields:   formFieldsValue(t, formField(unknown, "text", "Name")),
+			remote:   []remsclient.FieldTemplate{remote("fld1", "texta")},
+			expected: []string{"(unknown)"},
+		},
+		"fields reordered outside Terraform": {
+			fields:   formFieldsValue(t, formField(id("fld1"), "text", "Name"), formField(id("fld2"), "text", "Address")),
+			remote:   []remsclient.FieldTemplate{remote("fld2", "text"), remote("fld1", "text")},
+			expected: []string{"fld1", "fld2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields, matched, diags := withRemoteFormFieldIds(context.Background(), test.fields, test.remote)
+			requireNoErrors(t, diags)
+
+			assert.Equal(t, test.matched, matched)
+			assert.Equal(t, test.expected, formFieldIds(t, fields))
+		})
+	}
+}
+
+func TestFormResourceReadDrift(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/forms/3": `{
+	"form/id": 3,
+	"organization": {"organization/id": "umccr", "organization/short-name": {}, "organization/name": {}},
+	"form/internal-name": "Intake",
+	"form/title": "Intake",
+	"form/external-title": {"en": "Intake"},
+	"form/fields": [
+		{"field/id": "fld1", "field/type": "texta", "field/title": {"en": "Name"}, "field/optional": false}
+	],
+	"enabled": true,
+	"archived": false
+}`,
+	})
+	r := newTestResource(t, NewFormResource(), f)
+
+	prior := FormResourceModel{
+		Id:             types.Int64Value(3),
+		OrganizationId: types.StringValue("umccr"),
+		Title:          types.StringValue("Intake"),
+		Fields:         formFieldsValue(t, formField(types.StringValue("fld1"), "text", "Name")),
+	}
+
+	state, diags := r.Read(t, prior)
+	requireNoErrors(t, diags)
+
+	var read FormResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+
+	var fields []FormFieldResourceModel
+	requireNoErrors(t, read.Fields.ElementsAs(context.Background(), &fields, false))
+
+	assert.Len(t, fields, 1)
+	assert.Equal(t, types.StringValue("texta"), fields[0].Type, "expected the fields of REMS to replace ones that no longer match")
+}
+
+func TestFormResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := newTestResource(t, NewFormResource(), newFakeRems(t, map[string]string{}))
+
+	prior := tfsdk.State{Schema: formSchemaV0, Raw: tftypes.NewValue(formSchemaV0.Type().TerraformType(ctx), nil)}
+	requireNoErrors(t, prior.Set(ctx, struct {
+		Id             types.Int64                `tfsdk:"id"`
+		OrganizationId types.String               `tfsdk:"organization_id"`
+		Title          types.String               `tfsdk:"title"`
+		Fields         []formFieldResourceModelV0 `tfsdk:"fields"`
+	}{
+		Id:             types.Int64Value(3),
+		OrganizationId: types.StringValue("umccr"),
+		Title:          types.StringValue("Intake"),
+		Fields: []formFieldResourceModelV0{{
+			Id:          types.StringValue("fld1"),
+			Type:        types.StringValue("text"),
+			Title:       types.MapValueMust(types.StringType, map[string]attr.Value{"en": types.StringValue("Name")}),
+			Info:        types.StringValue("Your full name"),
+			Placeholder: types.StringNull(),
+			Optional:    types.BoolValue(false),
+		}},
+	}))
+
+	upgrader := r.resource.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+
+	resp := resource.UpgradeStateResponse{State: r.state(t, nil)}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+
+	var upgraded FormResourceModel
+	requireNoErrors(t, resp.State.Get(ctx, &upgraded))
+
+	assert.Equal(t, types.Int64Value(3), upgraded.Id)
+	assert.Equal(t, types.StringValue("Intake"), upgraded.Title)
+	assert.Equal(t, formFieldsValue(t, formField(types.StringValue("fld1"), "text", "Name")), upgraded.Fields)
+}
+
+func TestFormResourceDelete(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/forms/archived": `{"success": true}`,
+	})
+	r := newTestResource(t, NewFormResource(), f)
+
+	prior := FormResourceModel{
+		Id:             types.Int64Value(3),
+		OrganizationId: types.StringValue("umccr"),
+		Title:          types.StringValue("Intake"),
+		Fields:         formFieldsValue(t, formField(types.StringValue("fld1"), "text", "Name")),
+	}
+
+	requireNoErrors(t, r.Delete(t, prior))
+
+	assert.Equal(t, map[string]interface{}{"id": float64(3), "archived": true}, f.Request(t, "PUT /api/forms/archived").Body)
+}
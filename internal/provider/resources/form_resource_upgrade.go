@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/form_fields"
+)
+
+// formFieldResourceModelV0 is a form field as kept in version 0 of the state,
+// when info and placeholder were plain strings.
+type formFieldResourceModelV0 struct {
+	Id          types.String `tfsdk:"id"`
+	Type        types.String `tfsdk:"type"`
+	Title       types.Map    `tfsdk:"title"`
+	Info        types.String `tfsdk:"info"`
+	Placeholder types.String `tfsdk:"placeholder"`
+	Optional    types.Bool   `tfsdk:"optional"`
+}
+
+var formSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			Computed: true,
+		},
+		"organization_id": schema.StringAttribute{
+			Required: true,
+		},
+		"title": schema.StringAttribute{
+			Required: true,
+		},
+		"fields": schema.ListNestedAttribute{
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Optional: true,
+					},
+					"type": schema.StringAttribute{
+						Required: true,
+					},
+					"title": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"info": schema.StringAttribute{
+						Optional: true,
+					},
+					"placeholder": schema.StringAttribute{
+						Optional: true,
+					},
+					"optional": schema.BoolAttribute{
+						Optional: true,
+					},
+				},
+			},
+			Required: true,
+		},
+	},
+}
+
+func (r *FormResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &formSchemaV0,
+			StateUpgrader: upgradeFormStateV0,
+		},
+	}
+}
+
+// upgradeFormStateV0 turns the info and placeholder of fields into maps keyed by
+// language. Version 0 never sent either of them to REMS, so the form holds
+// neither and they are upgraded to null - a configuration that sets them will
+// plan to add them.
+func upgradeFormStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior struct {
+		Id             types.Int64  `tfsdk:"id"`
+		OrganizationId types.String `tfsdk:"organization_id"`
+		Title          types.String `tfsdk:"title"`
+		Fields         types.List   `tfsdk:"fields"`
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorFields := []formFieldResourceModelV0{}
+	resp.Diagnostics.Append(prior.Fields.ElementsAs(ctx, &priorFields, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optionType := types.ObjectType{AttrTypes: form_fields.OptionAttributeTypes}
+	fields := make([]FormFieldResourceModel, 0, len(priorFields))
+
+	for _, f := range priorFields {
+		fields = append(fields, FormFieldResourceModel{
+			Id:          f.Id,
+			Type:        f.Type,
+			Title:       f.Title,
+			Info:        types.MapNull(types.StringType),
+			Placeholder: types.MapNull(types.StringType),
+			Optional:    f.Optional,
+			MaxLength:   types.Int64Null(),
+			Options:     types.ListNull(optionType),
+			Columns:     types.ListNull(optionType),
+			Privacy:     types.StringNull(),
+			Visibility:  types.ObjectNull(form_fields.VisibilityAttributeTypes),
+		})
+	}
+
+	upgraded := FormResourceModel{
+		Id:             prior.Id,
+		OrganizationId: prior.OrganizationId,
+		Title:          prior.Title,
+	}
+
+	var d diag.Diagnostics
+	upgraded.Fields, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: form_fields.AttributeTypes}, fields)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The kinds of REMS object, as recorded in the type of a resource identity.
+const (
+	identityTypeBlacklistEntry    = "blacklist-entry"
+	identityTypeCatalogueItem     = "catalogue-item"
+	identityTypeCategory          = "category"
+	identityTypeForm              = "form"
+	identityTypeInvitation        = "invitation"
+	identityTypeLicense           = "license"
+	identityTypeLicenseAttachment = "license-attachment"
+	identityTypeResource          = "resource"
+	identityTypeUser              = "user"
+	identityTypeWorkflow          = "workflow"
+)
+
+// objectIdentityModel is the identity of a REMS object that belongs to no
+// organization.
+type objectIdentityModel struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Type types.String `tfsdk:"type"`
+}
+
+func newObjectIdentity(objectType string, id types.Int64) objectIdentityModel {
+	return objectIdentityModel{
+		Id:   id,
+		Type: types.StringValue(objectType),
+	}
+}
+
+var objectIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.Int64Attribute{
+			Description:       "REMS internal identifier of the object",
+			RequiredForImport: true,
+		},
+		"type": identityschema.StringAttribute{
+			Description:       "Kind of REMS object",
+			OptionalForImport: true,
+		},
+	},
+}
+
+// organizationObjectIdentityModel is the identity of a REMS object that is owned
+// by an organization.
+type organizationObjectIdentityModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Type           types.String `tfsdk:"type"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+}
+
+func newOrganizationObjectIdentity(objectType string, id types.Int64, organizationId types.String) organizationObjectIdentityModel {
+	return organizationObjectIdentityModel{
+		Id:             id,
+		Type:           types.StringValue(objectType),
+		OrganizationId: organizationId,
+	}
+}
+
+var organizationObjectIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.Int64Attribute{
+			Description:       "REMS internal identifier of the object",
+			RequiredForImport: true,
+		},
+		"type": identityschema.StringAttribute{
+			Description:       "Kind of REMS object",
+			OptionalForImport: true,
+		},
+		"organization_id": identityschema.StringAttribute{
+			Description:       "Organization that owns the object",
+			OptionalForImport: true,
+		},
+	},
+}
+
+// userIdentityModel is the identity of a REMS user, who is identified by their
+// user id rather than a number.
+type userIdentityModel struct {
+	Userid types.String `tfsdk:"userid"`
+	Type   types.String `tfsdk:"type"`
+}
+
+var userIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"userid": identityschema.StringAttribute{
+			Description:       "REMS user id of the user",
+			RequiredForImport: true,
+		},
+		"type": identityschema.StringAttribute{
+			Description:       "Kind of REMS object",
+			OptionalForImport: true,
+		},
+	},
+}
+
+// blacklistEntryIdentityModel is the identity of a blacklist entry, which REMS
+// keys by the user and the resource rather than an id of its own.
+type blacklistEntryIdentityModel struct {
+	UserId        types.String `tfsdk:"user_id"`
+	ResourceResid types.String `tfsdk:"resource_resid"`
+	Type          types.String `tfsdk:"type"`
+}
+
+var blacklistEntryIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"user_id": identityschema.StringAttribute{
+			Description:       "REMS user id of the blacklisted user",
+			RequiredForImport: true,
+		},
+		"resource_resid": identityschema.StringAttribute{
+			Description:       "External identifier of the resource the user is blacklisted from",
+			RequiredForImport: true,
+		},
+		"type": identityschema.StringAttribute{
+			Description:       "Kind of REMS object",
+			OptionalForImport: true,
+		},
+	},
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importStateInt64Id is the equivalent of resource.ImportStatePassthroughID for
+// the REMS objects that are identified by a number. The number comes either from
+// the import id or, for an import block with an identity, from the id of the
+// identity - in which case any type given must be objectType. The rest of the
+// identity is filled in by the Read that follows the import, which checks any
+// organization_id given (see checkImportedOrganization).
+func importStateInt64Id(ctx context.Context, objectType string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		id, err := strconv.ParseInt(req.ID, 10, 64)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid import identifier",
+				fmt.Sprintf("Expected the numeric REMS identifier of the object to import, got: %q", req.ID),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
+	var id types.Int64
+	var identityType types.String
+
+	resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("type"), &identityType)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !identityType.IsNull() && identityType.ValueString() != objectType {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid import identity",
+			fmt.Sprintf("Expected an identity of type %q, got: %q", objectType, identityType.ValueString()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// checkImportedOrganization fails the Read that follows an import by identity
+// when the identity names an organization other than the one owning the object
+// REMS returned. The state has no organization_id until that first Read, so later
+// Reads - where a change of organization is drift like any other - are left alone.
+func checkImportedOrganization(ctx context.Context, priorOrganizationId types.String, identity *tfsdk.ResourceIdentity, organizationId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !priorOrganizationId.IsNull() || identity == nil || identity.Raw.IsNull() {
+		return diags
+	}
+
+	var expected types.String
+
+	diags.Append(identity.GetAttribute(ctx, path.Root("organization_id"), &expected)...)
+
+	if diags.HasError() || expected.IsNull() || expected.ValueString() == organizationId {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("organization_id"),
+		"Invalid import identity",
+		fmt.Sprintf("Expected an object owned by organization %q, but REMS has it owned by %q", expected.ValueString(), organizationId),
+	)
+
+	return diags
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportStateInt64Id(t *testing.T) {
+	tests := map[string]struct {
+		id          string
+		identity    *organizationObjectIdentityModel
+		expected    types.Int64
+		expectError bool
+	}{
+		"id": {
+			id:       "12",
+			expected: types.Int64Value(12),
+		},
+		"id that is not a number": {
+			id:          "dataset",
+			expectError: true,
+		},
+		"identity": {
+			identity: &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringValue(identityTypeResource), OrganizationId: types.StringValue("umccr")},
+			expected: types.Int64Value(12),
+		},
+		"identity without a type": {
+			identity: &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringNull(), OrganizationId: types.StringNull()},
+			expected: types.Int64Value(12),
+		},
+		"identity of another type": {
+			identity:    &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringValue(identityTypeForm), OrganizationId: types.StringNull()},
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := newTestResource(t, NewResourceResource(), newFakeRems(t, map[string]string{}))
+
+			req := resource.ImportStateRequest{ID: test.id}
+
+			if test.identity != nil {
+				req.Identity = r.identity()
+				requireNoErrors(t, req.Identity.Set(ctx, test.identity))
+			}
+
+			resp := resource.ImportStateResponse{State: r.state(t, nil), Identity: r.identity()}
+			r.resource.(resource.ResourceWithImportState).ImportState(ctx, req, &resp)
+
+			if test.expectError {
+				assert.True(t, resp.Diagnostics.HasError(), "expected an error")
+				return
+			}
+
+			requireNoErrors(t, resp.Diagnostics)
+
+			var id types.Int64
+			requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("id"), &id))
+			assert.Equal(t, test.expected, id)
+		})
+	}
+}
+
+func TestCheckImportedOrganization(t *testing.T) {
+	tests := map[string]struct {
+		prior       types.String
+		identity    *organizationObjectIdentityModel
+		expectError bool
+	}{
+		"imported by id": {
+			prior: types.StringNull(),
+		},
+		"identity of the owning organization": {
+			prior:    types.StringNull(),
+			identity: &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringNull(), OrganizationId: types.StringValue("umccr")},
+		},
+		"identity without an organization": {
+			prior:    types.StringNull(),
+			identity: &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringNull(), OrganizationId: types.StringNull()},
+		},
+		"identity of another organization": {
+			prior:       types.StringNull(),
+			identity:    &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringNull(), OrganizationId: types.StringValue("garvan")},
+			expectError: true,
+		},
+		"refresh after the organization changed": {
+			prior:    types.StringValue("garvan"),
+			identity: &organizationObjectIdentityModel{Id: types.Int64Value(12), Type: types.StringValue(identityTypeResource), OrganizationId: types.StringValue("garvan")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := newTestResource(t, NewResourceResource(), newFakeRems(t, map[string]string{}))
+
+			identity := r.identity()
+
+			if test.identity != nil {
+				requireNoErrors(t, identity.Set(ctx, test.identity))
+			}
+
+			diags := checkImportedOrganization(ctx, test.prior, identity, "umccr")
+
+			assert.Equal(t, test.expectError, diags.HasError(), "%v", diags)
+		})
+	}
+}
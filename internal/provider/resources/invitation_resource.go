@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &InvitationResource{}
+var _ resource.ResourceWithImportState = &InvitationResource{}
+var _ resource.ResourceWithIdentity = &InvitationResource{}
+var _ resource.ResourceWithModifyPlan = &InvitationResource{}
+
+func NewInvitationResource() resource.Resource {
+	return &InvitationResource{}
+}
+
+// InvitationResource defines the resource implementation.
+type InvitationResource struct {
+	client *remsclient.APIClient
+}
+
+// InvitationResourceModel describes the resource data model.
+type InvitationResourceModel struct {
+	Id            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Email         types.String `tfsdk:"email"`
+	WorkflowId    types.Int64  `tfsdk:"workflow_id"`
+	Sent          types.String `tfsdk:"sent"`
+	Accepted      types.String `tfsdk:"accepted"`
+	InvitedUserId types.String `tfsdk:"invited_user_id"`
+}
+
+func (r *InvitationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_invitation"
+}
+
+func (r *InvitationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Invitation, sent by email, for someone to become a handler of a workflow. REMS cannot edit " +
+			"invitations so a change to an invitation that has not been accepted yet replaces (and re-sends) it. " +
+			"Once accepted the invitation is history - changes only update the Terraform state, and destroying " +
+			"the resource leaves the handler in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Invitation internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the person invited",
+				Required:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address the invitation is sent to",
+				Required:            true,
+			},
+			"workflow_id": schema.Int64Attribute{
+				MarkdownDescription: "Id of the workflow the person is invited to handle",
+				Optional:            true,
+			},
+			"sent": schema.StringAttribute{
+				MarkdownDescription: "When the invitation email was sent (RFC 3339), if it has been",
+				Computed:            true,
+			},
+			"accepted": schema.StringAttribute{
+				MarkdownDescription: "When the invitation was accepted (RFC 3339), if it has been",
+				Computed:            true,
+			},
+			"invited_user_id": schema.StringAttribute{
+				MarkdownDescription: "User id of whoever accepted the invitation",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *InvitationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema
+}
+
+func (r *InvitationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan replaces an invitation that has not been accepted when anything about
+// it changes. An accepted invitation is left alone.
+func (r *InvitationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to decide when creating or destroying
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan InvitationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.Accepted.IsNull() {
+		return
+	}
+
+	if !plan.Name.Equal(state.Name) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name"))
+	}
+
+	if !plan.Email.Equal(state.Email) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("email"))
+	}
+
+	if !plan.WorkflowId.Equal(state.WorkflowId) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("workflow_id"))
+	}
+}
+
+func (r *InvitationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data InvitationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invitationConfig := remsclient.NewCreateInvitationCommand(data.Name.ValueString(), data.Email.ValueString())
+	invitationConfig.WorkflowId = data.WorkflowId.ValueInt64Pointer()
+
+	createResult, createResponse, createErr := r.client.InvitationsAPI.
+		ApiInvitationsCreatePost(context.Background()).
+		CreateInvitationCommand(*invitationConfig).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create invitation",
+			fmt.Sprintf("Could not create invitation: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success || createResult.InvitationId == nil {
+		resp.Diagnostics.AddError(
+			"Failure to create invitation",
+			fmt.Sprintf("Could not create invitation: %v", createResult.Errors),
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(*createResult.InvitationId)
+
+	// the invitation is sent as it is created, so read back when
+	invitation, err := r.invitation(data.Id.ValueInt64())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read invitation", err.Error())
+		return
+	}
+
+	if invitation != nil {
+		data.fromInvitation(invitation)
+	} else {
+		data.Sent = types.StringNull()
+		data.Accepted = types.StringNull()
+		data.InvitedUserId = types.StringNull()
+	}
+
+	tflog.Trace(ctx, "created an invitation")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *InvitationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InvitationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invitation, err := r.invitation(data.Id.ValueInt64())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read invitation", err.Error())
+		return
+	}
+
+	if invitation == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.fromInvitation(invitation)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *InvitationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only an accepted invitation is ever updated, and there is nothing to change in REMS
+	var data, state InvitationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Sent = state.Sent
+	data.Accepted = state.Accepted
+	data.InvitedUserId = state.InvitedUserId
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *InvitationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// REMS has no way to withdraw an invitation
+	tflog.Debug(ctx, "invitations are left in REMS when removed from Terraform")
+}
+
+func (r *InvitationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateInt64Id(ctx, identityTypeInvitation, req, resp)
+}
+
+// invitation finds an invitation in REMS, returning nil if there is none. There
+// is no endpoint for a single invitation.
+func (r *InvitationResource) invitation(id int64) (*remsclient.InvitationResponse, error) {
+	invitations, invitationsResponse, invitationsErr := r.client.InvitationsAPI.
+		ApiInvitationsGet(context.Background()).
+		Execute()
+
+	if invitationsErr != nil {
+		return nil, fmt.Errorf("Could not read invitation %d: %s %v", id, invitationsErr.Error(), invitationsResponse)
+	}
+
+	for i := range invitations {
+		if invitations[i].InvitationId != nil && *invitations[i].InvitationId == id {
+			return &invitations[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// fromInvitation sets the model from an invitation as returned by REMS. Once the
+// invitation is accepted the name, email and workflow are left as they are in the
+// model, as changing them no longer means anything.
+func (data *InvitationResourceModel) fromInvitation(invitation *remsclient.InvitationResponse) {
+	data.Id = types.Int64PointerValue(invitation.InvitationId)
+	data.Sent = timeValue(invitation.InvitationSent)
+	data.Accepted = timeValue(invitation.InvitationAccepted)
+
+	if invitation.InvitationInvitedUser != nil {
+		data.InvitedUserId = types.StringValue(invitation.InvitationInvitedUser.Userid)
+	} else {
+		data.InvitedUserId = types.StringNull()
+	}
+
+	if invitation.InvitationAccepted != nil && !data.Name.IsNull() {
+		return
+	}
+
+	data.Name = types.StringValue(invitation.InvitationName)
+	data.Email = types.StringValue(invitation.InvitationEmail)
+
+	if invitation.InvitationWorkflow != nil {
+		data.WorkflowId = types.Int64Value(invitation.InvitationWorkflow.WorkflowId)
+	} else {
+		data.WorkflowId = types.Int64Null()
+	}
+}
+
+func (data *InvitationResourceModel) identity() objectIdentityModel {
+	return newObjectIdentity(identityTypeInvitation, data.Id)
+}
+
+// timeValue is an RFC 3339 string value of an optional time.
+func timeValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.Format(time.RFC3339))
+}
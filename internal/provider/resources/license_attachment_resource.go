@@ -0,0 +1,414 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient_ext"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LicenseAttachmentResource{}
+var _ resource.ResourceWithImportState = &LicenseAttachmentResource{}
+var _ resource.ResourceWithIdentity = &LicenseAttachmentResource{}
+var _ resource.ResourceWithValidateConfig = &LicenseAttachmentResource{}
+var _ resource.ResourceWithModifyPlan = &LicenseAttachmentResource{}
+
+func NewLicenseAttachmentResource() resource.Resource {
+	return &LicenseAttachmentResource{}
+}
+
+// LicenseAttachmentResource defines the resource implementation.
+type LicenseAttachmentResource struct {
+	client *remsclient.APIClient
+}
+
+// LicenseAttachmentResourceModel describes the resource data model.
+type LicenseAttachmentResourceModel struct {
+	Id            types.Int64  `tfsdk:"id"`
+	Source        types.String `tfsdk:"source"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Filename      types.String `tfsdk:"filename"`
+	ContentSha256 types.String `tfsdk:"content_sha256"`
+}
+
+func (r *LicenseAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license_attachment"
+}
+
+func (r *LicenseAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "File attached to an attachment license, referred to by the `attachment_id` of a license " +
+			"localization. REMS cannot change an attachment so new content replaces the attachment - use " +
+			"`create_before_destroy` so that the license moves to the new attachment before the old one is removed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Attachment internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path of the file to upload. Exactly one of `source` or `content_base64` must be given.",
+				Optional:            true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "Content of the file to upload, base64 encoded (for instance with `filebase64()`)",
+				Optional:            true,
+			},
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "Name of the file as shown to applicants. Defaults to the base name of `source`, or `attachment` for `content_base64`. A change of name replaces the attachment.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"content_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the content, in hex. A change in the content (even of the same `source`) replaces the attachment. " +
+					"It is the hash of what was uploaded - REMS is only asked whether the attachment still exists, except on import.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *LicenseAttachmentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema
+}
+
+func (r *LicenseAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *LicenseAttachmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data LicenseAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Source.IsUnknown() || data.ContentBase64.IsUnknown() {
+		return
+	}
+
+	if data.Source.IsNull() == data.ContentBase64.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Invalid license attachment",
+			"Exactly one of source or content_base64 must be given.",
+		)
+		return
+	}
+
+	if !data.ContentBase64.IsNull() {
+		if _, err := base64.StdEncoding.DecodeString(data.ContentBase64.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_base64"),
+				"Invalid license attachment",
+				fmt.Sprintf("The content is not valid base64: %s", err.Error()),
+			)
+		}
+	}
+}
+
+// ModifyPlan works out the name and hash of the content to upload, replacing the
+// attachment if either has changed since it was uploaded - REMS cannot change an
+// attachment in place.
+func (r *LicenseAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan LicenseAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state *LicenseAttachmentResourceModel
+
+	if !req.State.Raw.IsNull() {
+		state = &LicenseAttachmentResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the content is only known at apply, by when it may well have changed
+	if plan.Source.IsUnknown() || plan.ContentBase64.IsUnknown() {
+		if state != nil {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_sha256"))
+		}
+		return
+	}
+
+	if plan.Filename.IsUnknown() {
+		plan.Filename = types.StringValue(plan.defaultFilename())
+	}
+
+	content, diags := plan.content()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ContentSha256 = types.StringValue(sha256Hex(content))
+
+	if state != nil {
+		if !plan.Filename.Equal(state.Filename) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("filename"))
+		}
+
+		if !plan.ContentSha256.Equal(state.ContentSha256) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_sha256"))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *LicenseAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LicenseAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Filename.IsUnknown() {
+		data.Filename = types.StringValue(data.defaultFilename())
+	}
+
+	content, diags := data.content()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ContentSha256 = types.StringValue(sha256Hex(content))
+
+	addResult, addResponse, addErr := remsclient_ext.AddLicenseAttachment(
+		context.Background(),
+		r.client,
+		filepath.Base(data.Filename.ValueString()),
+		content,
+	)
+
+	if addErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create license attachment",
+			fmt.Sprintf("Could not create license attachment: %s %v", addErr.Error(), addResponse),
+		)
+		return
+	}
+
+	if !addResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to create license attachment",
+			"Could not create license attachment",
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(addResult.Id)
+
+	tflog.Trace(ctx, "created a license attachment")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *LicenseAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data LicenseAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the headers are fetched: the hash of the content is the one uploaded,
+	// as REMS cannot change an attachment
+	metadata, metadataResponse, metadataErr := remsclient_ext.GetLicenseAttachmentMetadata(context.Background(), r.client, data.Id.ValueInt64())
+
+	if metadataResponse != nil && metadataResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if metadataErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read license attachment",
+			fmt.Sprintf("Could not read license attachment %d: %s %v", data.Id.ValueInt64(), metadataErr.Error(), metadataResponse),
+		)
+		return
+	}
+
+	// the name is only taken from REMS on import, as REMS may tidy it
+	if data.Filename.IsNull() && metadata.Filename != "" {
+		data.Filename = types.StringValue(metadata.Filename)
+	}
+
+	// an imported attachment has no hash to compare a configuration with, so its
+	// content is downloaded once
+	if data.ContentSha256.IsNull() {
+		attachmentResponse, attachmentErr := r.client.LicensesAPI.
+			ApiLicensesAttachmentsAttachmentIdGet(context.Background(), data.Id.ValueInt64()).
+			Execute()
+
+		if attachmentErr != nil {
+			resp.Diagnostics.AddError(
+				"Failure to read license attachment",
+				fmt.Sprintf("Could not read license attachment %d: %s %v", data.Id.ValueInt64(), attachmentErr.Error(), attachmentResponse),
+			)
+			return
+		}
+
+		content, err := io.ReadAll(attachmentResponse.Body)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failure to read license attachment",
+				fmt.Sprintf("Could not read license attachment %d: %s", data.Id.ValueInt64(), err.Error()),
+			)
+			return
+		}
+
+		data.ContentSha256 = types.StringValue(sha256Hex(content))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *LicenseAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// any change of content or name replaces the attachment, so only where it comes from can change in place
+	var data LicenseAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *LicenseAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data LicenseAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removeResult, removeResponse, removeErr := r.client.LicensesAPI.
+		ApiLicensesRemoveAttachmentPost(context.Background()).
+		AttachmentId(data.Id.ValueInt64()).
+		Execute()
+
+	if removeErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to remove license attachment",
+			fmt.Sprintf("Could not remove license attachment %d: %s %v", data.Id.ValueInt64(), removeErr.Error(), removeResponse),
+		)
+		return
+	}
+
+	if !removeResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to remove license attachment",
+			fmt.Sprintf("Could not remove license attachment %d: %v", data.Id.ValueInt64(), removeResult.GetErrors()),
+		)
+	}
+}
+
+func (r *LicenseAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateInt64Id(ctx, identityTypeLicenseAttachment, req, resp)
+}
+
+// content is the bytes to upload, from either the source file or content_base64.
+func (data *LicenseAttachmentResourceModel) content() ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !data.Source.IsNull() {
+		content, err := os.ReadFile(data.Source.ValueString())
+
+		if err != nil {
+			diags.AddAttributeError(path.Root("source"), "Invalid license attachment", fmt.Sprintf("Could not read the source file: %s", err.Error()))
+		}
+
+		return content, diags
+	}
+
+	content, err := base64.StdEncoding.DecodeString(data.ContentBase64.ValueString())
+
+	if err != nil {
+		diags.AddAttributeError(path.Root("content_base64"), "Invalid license attachment", fmt.Sprintf("The content is not valid base64: %s", err.Error()))
+	}
+
+	return content, diags
+}
+
+func (data *LicenseAttachmentResourceModel) defaultFilename() string {
+	if !data.Source.IsNull() {
+		return filepath.Base(data.Source.ValueString())
+	}
+
+	return "attachment"
+}
+
+func (data *LicenseAttachmentResourceModel) identity() objectIdentityModel {
+	return newObjectIdentity(identityTypeLicenseAttachment, data.Id)
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
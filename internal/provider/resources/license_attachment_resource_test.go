@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func licenseAttachmentModel(sha types.String) LicenseAttachmentResourceModel {
+	return LicenseAttachmentResourceModel{
+		Id:            types.Int64Value(5),
+		Source:        types.StringNull(),
+		ContentBase64: types.StringValue("e30="),
+		Filename:      types.StringValue("dta.json"),
+		ContentSha256: sha,
+	}
+}
+
+func TestLicenseAttachmentResourceRead(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"HEAD /api/licenses/attachments/5": ``,
+	})
+	r := newTestResource(t, NewLicenseAttachmentResource(), f)
+
+	prior := licenseAttachmentModel(types.StringValue("abc"))
+
+	state, diags := r.Read(t, prior)
+	requireNoErrors(t, diags)
+
+	var read LicenseAttachmentResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+	assert.Equal(t, prior, read)
+
+	for _, request := range f.Requests {
+		assert.Equal(t, "HEAD /api/licenses/attachments/5", request.Route, "expected the attachment not to be downloaded")
+	}
+}
+
+func TestLicenseAttachmentResourceReadImported(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"HEAD /api/licenses/attachments/5": ``,
+		"GET /api/licenses/attachments/5":  `{}`,
+	})
+	r := newTestResource(t, NewLicenseAttachmentResource(), f)
+
+	state, diags := r.Read(t, licenseAttachmentModel(types.StringNull()))
+	requireNoErrors(t, diags)
+
+	var read LicenseAttachmentResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+	assert.Equal(t, types.StringValue(sha256Hex([]byte(`{}`))), read.ContentSha256)
+}
+
+func TestLicenseAttachmentResourceReadMissing(t *testing.T) {
+	r := newTestResource(t, NewLicenseAttachmentResource(), newFakeRems(t, map[string]string{}))
+
+	state, diags := r.Read(t, licenseAttachmentModel(types.StringValue("abc")))
+	requireNoErrors(t, diags)
+	assert.True(t, state.Raw.IsNull(), "expected the attachment to be removed from state")
+}
+
+func TestLicenseAttachmentResourceModifyPlan(t *testing.T) {
+	r := newTestResource(t, NewLicenseAttachmentResource(), newFakeRems(t, map[string]string{}))
+
+	dir := t.TempDir()
+	for _, name := range []string{"v1/terms.pdf", "v2/terms.pdf", "v2/other.pdf"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(`{}`), 0o644))
+	}
+
+	uploaded := func(source string, contentBase64 string, filename string) *LicenseAttachmentResourceModel {
+		m := licenseAttachmentModel(types.StringValue(sha256Hex([]byte(`{}`))))
+		m.Source = types.StringNull()
+		m.ContentBase64 = types.StringNull()
+		if source != "" {
+			m.Source = types.StringValue(filepath.Join(dir, source))
+		}
+		if contentBase64 != "" {
+			m.ContentBase64 = types.StringValue(contentBase64)
+		}
+		m.Filename = types.StringValue(filename)
+		return &m
+	}
+	planned := func(source string, contentBase64 string, filename types.String) LicenseAttachmentResourceModel {
+		m := *uploaded(source, contentBase64, "")
+		m.Filename = filename
+		m.ContentSha256 = types.StringUnknown()
+		return m
+	}
+
+	unknownContent := planned("", "", types.StringUnknown())
+	unknownContent.ContentBase64 = types.StringUnknown()
+
+	tests := map[string]struct {
+		prior    *LicenseAttachmentResourceModel
+		planned  LicenseAttachmentResourceModel
+		filename types.String
+		replace  path.Paths
+	}{
+		"create": {
+			planned:  planned("v1/terms.pdf", "", types.StringUnknown()),
+			filename: types.StringValue("terms.pdf"),
+		},
+		"unchanged": {
+			prior:    uploaded("", "e30=", "dta.json"),
+			planned:  planned("", "e30=", types.StringValue("dta.json")),
+			filename: types.StringValue("dta.json"),
+		},
+		"moved source of the same name": {
+			prior:    uploaded("v1/terms.pdf", "", "terms.pdf"),
+			planned:  planned("v2/terms.pdf", "", types.StringUnknown()),
+			filename: types.StringValue("terms.pdf"),
+		},
+		"source of another name": {
+			prior:    uploaded("v1/terms.pdf", "", "terms.pdf"),
+			planned:  planned("v2/other.pdf", "", types.StringUnknown()),
+			filename: types.StringValue("other.pdf"),
+			replace:  path.Paths{path.Root("filename")},
+		},
+		"renamed": {
+			prior:    uploaded("v1/terms.pdf", "", "terms.pdf"),
+			planned:  planned("v1/terms.pdf", "", types.StringValue("terms-v1.pdf")),
+			filename: types.StringValue("terms-v1.pdf"),
+			replace:  path.Paths{path.Root("filename")},
+		},
+		"new content": {
+			prior:    uploaded("", "e30=", "attachment"),
+			planned:  planned("", "W10=", types.StringUnknown()),
+			filename: types.StringValue("attachment"),
+			replace:  path.Paths{path.Root("content_sha256")},
+		},
+		"content known only at apply": {
+			prior:    uploaded("", "e30=", "attachment"),
+			planned:  unknownContent,
+			filename: types.StringUnknown(),
+			replace:  path.Paths{path.Root("content_sha256")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			state := r.state(t, nil)
+			if test.prior != nil {
+				state = r.state(t, *test.prior)
+			}
+
+			plan := tfsdk.Plan(r.state(t, test.planned))
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			r.resource.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			var filename types.String
+			requireNoErrors(t, resp.Plan.GetAttribute(ctx, path.Root("filename"), &filename))
+
+			assert.Equal(t, test.filename, filename)
+			assert.Equal(t, test.replace, resp.RequiresReplace)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &LicenseListResource{}
+var _ list.ListResourceWithConfigure = &LicenseListResource{}
+
+func NewLicenseListResource() list.ListResource {
+	return &LicenseListResource{}
+}
+
+// LicenseListResource lists the licenses of a REMS instance.
+type LicenseListResource struct {
+	client *remsclient.APIClient
+}
+
+func (r *LicenseListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license"
+}
+
+func (r *LicenseListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists licenses",
+		Attributes:          listFilterAttributes("licenses"),
+	}
+}
+
+func (r *LicenseListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = listClient(req, resp)
+}
+
+func (r *LicenseListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter listFilterModel
+
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	licenses, licensesResponse, licensesErr := r.client.LicensesAPI.
+		ApiLicensesGet(ctx).
+		Archived(filter.Archived.ValueBool()).
+		Disabled(filter.Disabled.ValueBool()).
+		Execute()
+
+	if licensesErr != nil {
+		listHttpError(stream, "licenses", licensesErr, licensesResponse)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, license := range licenses {
+			if !filter.includes(license.Organization.OrganizationId) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+
+			titles := map[string]string{}
+			for lang, l := range license.Localizations {
+				titles[lang] = l.Title
+			}
+
+			result.DisplayName = LocalizedText(titles)
+
+			identity := newOrganizationObjectIdentity(identityTypeLicense, types.Int64Value(license.Id), types.StringValue(license.Organization.OrganizationId))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				var data LicenseResourceModel
+				result.Diagnostics.Append(data.fromLicense(ctx, &license)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -8,19 +8,23 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LicenseResource{}
 var _ resource.ResourceWithImportState = &LicenseResource{}
+var _ resource.ResourceWithIdentity = &LicenseResource{}
 
 func NewLicenseResource() resource.Resource {
 	return &LicenseResource{}
@@ -28,14 +32,29 @@ func NewLicenseResource() resource.Resource {
 
 // LicenseResource defines the resource implementation.
 type LicenseResource struct {
-	client *http.Client
+	client *remsclient.APIClient
 }
 
+// LicenseLocalizationResourceModel is the text of a license in a single language.
+type LicenseLocalizationResourceModel struct {
+	Title        types.String `tfsdk:"title"`
+	Textcontent  types.String `tfsdk:"textcontent"`
+	AttachmentId types.Int64  `tfsdk:"attachment_id"`
+}
+
+// licenseLocalizationType is the Terraform type of a LicenseLocalizationResourceModel.
+var licenseLocalizationType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"title":         types.StringType,
+	"textcontent":   types.StringType,
+	"attachment_id": types.Int64Type,
+}}
+
 // LicenseResourceModel describes the resource data model.
 type LicenseResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	Id             types.Int64  `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	Localizations  types.Map    `tfsdk:"localizations"`
 }
 
 func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,42 +64,72 @@ func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataReq
 func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Example resource",
+		MarkdownDescription: "License. REMS does not allow licenses to be edited so any change replaces the license.",
 
 		Attributes: map[string]schema.Attribute{
-			"configurable_attribute": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
-				Optional:            true,
-			},
-			"defaulted": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute with default value",
-				Optional:            true,
+			"id": schema.Int64Attribute{
 				Computed:            true,
-				Default:             stringdefault.StaticString("example value when not configured"),
+				MarkdownDescription: "License internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"id": schema.StringAttribute{
-				Computed:            true,
-				MarkdownDescription: "Example identifier",
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization that owns the license",
+				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "License type, one of `text`, `link` or `attachment`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"localizations": schema.MapNestedAttribute{
+				MarkdownDescription: "License text keyed by language",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							Required: true,
+						},
+						"textcontent": schema.StringAttribute{
+							MarkdownDescription: "The license text, or the URL for a `link` license",
+							Required:            true,
+						},
+						"attachment_id": schema.Int64Attribute{
+							MarkdownDescription: "For `attachment` licenses, the uploaded attachment",
+							Optional:            true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
 				},
 			},
 		},
 	}
 }
 
+func (r *LicenseResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationObjectIdentitySchema
+}
+
 func (r *LicenseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*remsclient.APIClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -99,24 +148,55 @@ func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	modelLocalizations := map[string]LicenseLocalizationResourceModel{}
+	resp.Diagnostics.Append(data.Localizations.ElementsAs(ctx, &modelLocalizations, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	localizations := map[string]remsclient.LicenseLocalization{}
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	for lang, l := range modelLocalizations {
+		localization := remsclient.NewLicenseLocalization(l.Title.ValueString(), l.Textcontent.ValueString())
+		if !l.AttachmentId.IsNull() {
+			localization.SetAttachmentId(l.AttachmentId.ValueInt64())
+		}
+		localizations[lang] = *localization
+	}
+
+	orgId := remsclient.NewOrganizationId(data.OrganizationId.ValueString())
+
+	licenseConfig := remsclient.NewCreateLicenseCommand(data.Type.ValueString(), *orgId, localizations)
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	createResult, createResponse, createErr := r.client.LicensesAPI.
+		ApiLicensesCreatePost(context.Background()).
+		CreateLicenseCommand(*licenseConfig).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create license",
+			fmt.Sprintf("Could not create license: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to create license",
+			fmt.Sprintf("Could not create license: %v", createResult.GetErrors()),
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(createResult.GetId())
+
+	tflog.Trace(ctx, "created a license")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -129,19 +209,48 @@ func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	license, licenseResponse, licenseErr := r.client.LicensesAPI.
+		ApiLicensesLicenseIdGet(context.Background(), data.Id.ValueInt64()).
+		Execute()
+
+	if licenseResponse != nil && licenseResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if licenseErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read license",
+			fmt.Sprintf("Could not read license %d: %s %v", data.Id.ValueInt64(), licenseErr.Error(), licenseResponse),
+		)
+		return
+	}
+
+	// an archived license is as good as deleted
+	if license.Archived {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkImportedOrganization(ctx, data.OrganizationId, req.Identity, license.Organization.OrganizationId)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromLicense(ctx, license)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every attribute requires replacement, so there is never anything to update in place
 	var data LicenseResourceModel
 
 	// Read Terraform plan data into the model
@@ -151,16 +260,9 @@ func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
-
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -173,15 +275,55 @@ func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	// REMS never deletes licenses (applications refer to them) - the best we can do is archive
+	archiveResult, archiveResponse, archiveErr := r.client.LicensesAPI.
+		ApiLicensesArchivedPut(context.Background()).
+		ArchivedCommand(*remsclient.NewArchivedCommand(data.Id.ValueInt64(), true)).
+		Execute()
+
+	if archiveErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to archive license",
+			fmt.Sprintf("Could not archive license %d: %s %v", data.Id.ValueInt64(), archiveErr.Error(), archiveResponse),
+		)
+		return
+	}
+
+	if !archiveResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to archive license",
+			fmt.Sprintf("Could not archive license %d: %v", data.Id.ValueInt64(), archiveResult.GetErrors()),
+		)
+	}
 }
 
 func (r *LicenseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateInt64Id(ctx, identityTypeLicense, req, resp)
+}
+
+// fromLicense sets the model from a license as returned by REMS.
+func (data *LicenseResourceModel) fromLicense(ctx context.Context, license *remsclient.License) diag.Diagnostics {
+	data.Id = types.Int64Value(license.Id)
+	data.OrganizationId = types.StringValue(license.Organization.OrganizationId)
+	data.Type = types.StringValue(license.Licensetype)
+
+	localizations := map[string]LicenseLocalizationResourceModel{}
+
+	for lang, l := range license.Localizations {
+		localizations[lang] = LicenseLocalizationResourceModel{
+			Title:        types.StringValue(l.Title),
+			Textcontent:  types.StringValue(l.Textcontent),
+			AttachmentId: types.Int64PointerValue(l.AttachmentId.Get()),
+		}
+	}
+
+	var diags diag.Diagnostics
+
+	data.Localizations, diags = types.MapValueFrom(ctx, licenseLocalizationType, localizations)
+
+	return diags
+}
+
+func (data *LicenseResourceModel) identity() organizationObjectIdentityModel {
+	return newOrganizationObjectIdentity(identityTypeLicense, data.Id, data.OrganizationId)
 }
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func licenseModel(t *testing.T, id types.Int64) LicenseResourceModel {
+	localizations, diags := types.MapValueFrom(context.Background(), licenseLocalizationType, map[string]LicenseLocalizationResourceModel{
+		"en": {
+			Title:        types.StringValue("Data Transfer Agreement"),
+			Textcontent:  types.StringValue("https://example.org/dta.pdf"),
+			AttachmentId: types.Int64Null(),
+		},
+	})
+	requireNoErrors(t, diags)
+
+	return LicenseResourceModel{
+		Id:             id,
+		OrganizationId: types.StringValue("umccr"),
+		Type:           types.StringValue("link"),
+		Localizations:  localizations,
+	}
+}
+
+const licenseJson = `{
+	"id": 7,
+	"licensetype": "link",
+	"organization": {"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}},
+	"enabled": true,
+	"archived": false,
+	"localizations": {"en": {"title": "Data Transfer Agreement", "textcontent": "https://example.org/dta.pdf"}}
+}`
+
+func TestLicenseResourceCreate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"POST /api/licenses/create": `{"success": true, "id": 7}`,
+	})
+	r := newTestResource(t, NewLicenseResource(), f)
+
+	state, diags := r.Create(t, licenseModel(t, types.Int64Unknown()))
+	requireNoErrors(t, diags)
+
+	var created LicenseResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &created))
+	assert.Equal(t, types.Int64Value(7), created.Id)
+
+	body := f.Request(t, "POST /api/licenses/create").Body
+	assert.Equal(t, "link", body["licensetype"])
+	assert.Equal(t, map[string]interface{}{"organization/id": "umccr"}, body["organization"])
+	assert.Equal(t, map[string]interface{}{
+		"en": map[string]interface{}{"title": "Data Transfer Agreement", "textcontent": "https://example.org/dta.pdf"},
+	}, body["localizations"])
+}
+
+func TestLicenseResourceCreateFailure(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"POST /api/licenses/create": `{"success": false, "errors": [{"type": "t.actions.errors/invalid-organization"}]}`,
+	})
+	r := newTestResource(t, NewLicenseResource(), f)
+
+	_, diags := r.Create(t, licenseModel(t, types.Int64Unknown()))
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Failure to create license", diags[0].Summary())
+}
+
+func TestLicenseResourceRead(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/licenses/7": licenseJson,
+	})
+	r := newTestResource(t, NewLicenseResource(), f)
+
+	prior := licenseModel(t, types.Int64Value(7))
+	prior.OrganizationId = types.StringValue("someone-else")
+
+	state, diags := r.Read(t, prior)
+	requireNoErrors(t, diags)
+
+	var read LicenseResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+	assert.Equal(t, licenseModel(t, types.Int64Value(7)), read)
+}
+
+func TestLicenseResourceReadGone(t *testing.T) {
+	for name, routes := range map[string]map[string]string{
+		"missing":  {},
+		"archived": {"GET /api/licenses/7": `{"id": 7, "licensetype": "link", "organization": {"organization/id": "umccr", "organization/short-name": {}, "organization/name": {}}, "enabled": true, "archived": true, "localizations": {}}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := newTestResource(t, NewLicenseResource(), newFakeRems(t, routes))
+
+			state, diags := r.Read(t, licenseModel(t, types.Int64Value(7)))
+			requireNoErrors(t, diags)
+			assert.True(t, state.Raw.IsNull(), "expected the license to be removed from state")
+		})
+	}
+}
+
+func TestLicenseResourceDelete(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/licenses/archived": `{"success": true}`,
+	})
+	r := newTestResource(t, NewLicenseResource(), f)
+
+	requireNoErrors(t, r.Delete(t, licenseModel(t, types.Int64Value(7))))
+
+	assert.Equal(t, map[string]interface{}{"id": float64(7), "archived": true}, f.Request(t, "PUT /api/licenses/archived").Body)
+}
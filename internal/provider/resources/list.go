@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// listFilterModel is the configuration shared by the list resources. The REMS
+// list endpoints leave out archived and disabled objects unless asked for them.
+type listFilterModel struct {
+	OrganizationId types.String `tfsdk:"organization_id"`
+	Archived       types.Bool   `tfsdk:"archived"`
+	Disabled       types.Bool   `tfsdk:"disabled"`
+}
+
+// includes is whether an object owned by organizationId passes the organization filter.
+func (f listFilterModel) includes(organizationId string) bool {
+	return f.OrganizationId.IsNull() || f.OrganizationId.ValueString() == organizationId
+}
+
+// listFilterAttributes returns the schema of listFilterModel, describing the
+// listed objects with noun.
+func listFilterAttributes(noun string) map[string]listschema.Attribute {
+	return map[string]listschema.Attribute{
+		"organization_id": listschema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Only list %s owned by this organization", noun),
+			Optional:            true,
+		},
+		"archived": listschema.BoolAttribute{
+			MarkdownDescription: fmt.Sprintf("Include archived %s", noun),
+			Optional:            true,
+		},
+		"disabled": listschema.BoolAttribute{
+			MarkdownDescription: fmt.Sprintf("Include disabled %s", noun),
+			Optional:            true,
+		},
+	}
+}
+
+// listClient is the Configure of every list resource.
+func listClient(req resource.ConfigureRequest, resp *resource.ConfigureResponse) *remsclient.APIClient {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+
+	return client
+}
+
+// listError ends a list with a single error.
+func listError(stream *list.ListResultsStream, summary string, detail string) {
+	var diags diag.Diagnostics
+	diags.AddError(summary, detail)
+	stream.Results = list.ListResultsStreamDiagnostics(diags)
+}
+
+// listHttpError ends a list with the error of a failed REMS call.
+func listHttpError(stream *list.ListResultsStream, noun string, err error, httpResp *http.Response) {
+	listError(stream, fmt.Sprintf("Failure to list %s", noun), fmt.Sprintf("Could not list %s: %s %v", noun, err.Error(), httpResp))
+}
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// fakeRemsRequest is a request made of a fakeRems.
+type fakeRemsRequest struct {
+	Route string
+	Query url.Values
+	Body  map[string]interface{}
+}
+
+// fakeRems is a REMS server for tests. It answers each "METHOD /path" route with
+// canned JSON, anything else with a 404, and records the requests made of it.
+type fakeRems struct {
+	Routes   map[string]string
+	Requests []fakeRemsRequest
+
+	server *httptest.Server
+}
+
+func newFakeRems(t *testing.T, routes map[string]string) *fakeRems {
+	f := &fakeRems{Routes: routes}
+
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := fakeRemsRequest{Route: r.Method + " " + r.URL.Path, Query: r.URL.Query()}
+
+		if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+			if err := json.Unmarshal(body, &request.Body); err != nil {
+				t.Errorf("%s sent a body that is not a JSON object: %s", request.Route, body)
+			}
+		}
+
+		f.Requests = append(f.Requests, request)
+
+		response, ok := f.Routes[request.Route]
+
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// Client is a REMS client pointing at the fake server.
+func (f *fakeRems) Client() *remsclient.APIClient {
+	serverUrl, _ := url.Parse(f.server.URL)
+
+	cfg := remsclient.NewConfiguration()
+	cfg.Host = serverUrl.Host
+	cfg.Scheme = serverUrl.Scheme
+	cfg.HTTPClient = f.server.Client()
+
+	return remsclient.NewAPIClient(cfg)
+}
+
+// Request is the last request made to the route, failing the test if there was none.
+func (f *fakeRems) Request(t *testing.T, route string) fakeRemsRequest {
+	t.Helper()
+
+	for i := len(f.Requests) - 1; i >= 0; i-- {
+		if f.Requests[i].Route == route {
+			return f.Requests[i]
+		}
+	}
+
+	t.Fatalf("expected a request to %s, got %v", route, f.Requests)
+
+	return fakeRemsRequest{}
+}
+
+// testResource is a resource configured with a client for the fake server, along
+// with its schemas.
+type testResource struct {
+	resource       resource.Resource
+	schema         resource.SchemaResponse
+	identitySchema *resource.IdentitySchemaResponse
+}
+
+func newTestResource(t *testing.T, r resource.Resource, f *fakeRems) *testResource {
+	t.Helper()
+
+	ctx := context.Background()
+	tr := &testResource{resource: r}
+
+	r.Schema(ctx, resource.SchemaRequest{}, &tr.schema)
+	requireNoErrors(t, tr.schema.Diagnostics)
+
+	if withIdentity, ok := r.(resource.ResourceWithIdentity); ok {
+		tr.identitySchema = &resource.IdentitySchemaResponse{}
+		withIdentity.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, tr.identitySchema)
+		requireNoErrors(t, tr.identitySchema.Diagnostics)
+	}
+
+	if withConfigure, ok := r.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		withConfigure.Configure(ctx, resource.ConfigureRequest{ProviderData: f.Client()}, &configureResp)
+		requireNoErrors(t, configureResp.Diagnostics)
+	}
+
+	return tr
+}
+
+// state is an empty state of the resource, or one holding the model if given.
+func (tr *testResource) state(t *testing.T, model interface{}) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{Schema: tr.schema.Schema, Raw: tftypes.NewValue(tr.schema.Schema.Type().TerraformType(context.Background()), nil)}
+
+	if model != nil {
+		requireNoErrors(t, state.Set(context.Background(), model))
+	}
+
+	return state
+}
+
+func (tr *testResource) identity() *tfsdk.ResourceIdentity {
+	if tr.identitySchema == nil {
+		return nil
+	}
+
+	return &tfsdk.ResourceIdentity{
+		Schema: tr.identitySchema.IdentitySchema,
+		Raw:    tftypes.NewValue(tr.identitySchema.IdentitySchema.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+// Create creates the planned model, returning the resulting state.
+func (tr *testResource) Create(t *testing.T, plan interface{}) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	planned := tr.state(t, plan)
+
+	resp := resource.CreateResponse{State: tr.state(t, nil), Identity: tr.identity()}
+	tr.resource.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan(planned)}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+// Read refreshes the prior state model, returning the resulting state.
+func (tr *testResource) Read(t *testing.T, prior interface{}) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	state := tr.state(t, prior)
+
+	resp := resource.ReadResponse{State: state, Identity: tr.identity()}
+	tr.resource.Read(context.Background(), resource.ReadRequest{State: state, Identity: tr.identity()}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+// Update updates from the prior state model to the planned model, returning the
+// resulting state.
+func (tr *testResource) Update(t *testing.T, prior interface{}, plan interface{}) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	planned := tr.state(t, plan)
+
+	resp := resource.UpdateResponse{State: planned, Identity: tr.identity()}
+	tr.resource.Update(context.Background(), resource.UpdateRequest{State: tr.state(t, prior), Plan: tfsdk.Plan(planned)}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+// Delete destroys the prior state model.
+func (tr *testResource) Delete(t *testing.T, prior interface{}) diag.Diagnostics {
+	t.Helper()
+
+	state := tr.state(t, prior)
+
+	resp := resource.DeleteResponse{State: state}
+	tr.resource.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+
+	return resp.Diagnostics
+}
+
+func requireNoErrors(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", diags)
+	}
+}
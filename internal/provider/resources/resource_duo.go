@@ -0,0 +1,472 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// ResourceDuoCodeResourceModel is a Data Use Ontology code of a resource.
+type ResourceDuoCodeResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	MoreInfo    types.Map    `tfsdk:"more_info"`
+	Restriction types.Object `tfsdk:"restriction"`
+}
+
+// ResourceDuoRestrictionResourceModel is the restriction of a DUO code, such as the
+// diseases a DUO:0000007 (disease specific research) code is limited to. REMS
+// keeps restrictions as a list of types each with a list of values - here each
+// type is an attribute of its own.
+type ResourceDuoRestrictionResourceModel struct {
+	Mondo         types.List   `tfsdk:"mondo"`
+	Topic         types.String `tfsdk:"topic"`
+	Location      types.String `tfsdk:"location"`
+	Institute     types.String `tfsdk:"institute"`
+	Collaboration types.String `tfsdk:"collaboration"`
+	Project       types.String `tfsdk:"project"`
+	Users         types.String `tfsdk:"users"`
+	Date          types.String `tfsdk:"date"`
+	Months        types.Int64  `tfsdk:"months"`
+}
+
+var duoRestrictionType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"mondo":         types.ListType{ElemType: types.StringType},
+	"topic":         types.StringType,
+	"location":      types.StringType,
+	"institute":     types.StringType,
+	"collaboration": types.StringType,
+	"project":       types.StringType,
+	"users":         types.StringType,
+	"date":          types.StringType,
+	"months":        types.Int64Type,
+}}
+
+var duoCodeType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":          types.StringType,
+	"more_info":   types.MapType{ElemType: types.StringType},
+	"restriction": duoRestrictionType,
+}}
+
+// duoCodeBlock is the schema of the duo_code blocks of a resource.
+var duoCodeBlock = schema.ListNestedBlock{
+	MarkdownDescription: "Data Use Ontology (DUO) code describing the conditions of use of the resource",
+	PlanModifiers: []planmodifier.List{
+		listplanmodifier.RequiresReplace(),
+	},
+	NestedObject: schema.NestedBlockObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "DUO code id, such as `DUO:0000007`",
+				Required:            true,
+			},
+			"more_info": schema.MapAttribute{
+				MarkdownDescription: "Further explanation of the code for this resource, keyed by language",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"restriction": schema.SingleNestedBlock{
+				MarkdownDescription: "Restriction of the code. Give the attribute matching the code, for instance `mondo` " +
+					"for DUO:0000007 (disease specific research) or `location` for DUO:0000022 (geographical restriction).",
+				Attributes: map[string]schema.Attribute{
+					"mondo": schema.ListAttribute{
+						MarkdownDescription: "MONDO ids of the diseases the use is limited to",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"topic": schema.StringAttribute{
+						MarkdownDescription: "Research topic the use is limited to",
+						Optional:            true,
+					},
+					"location": schema.StringAttribute{
+						MarkdownDescription: "Geographical area the use is limited to",
+						Optional:            true,
+					},
+					"institute": schema.StringAttribute{
+						MarkdownDescription: "Institutions the use is limited to",
+						Optional:            true,
+					},
+					"collaboration": schema.StringAttribute{
+						MarkdownDescription: "Study or investigators collaboration is required with",
+						Optional:            true,
+					},
+					"project": schema.StringAttribute{
+						MarkdownDescription: "Project the use is limited to",
+						Optional:            true,
+					},
+					"users": schema.StringAttribute{
+						MarkdownDescription: "Users the use is limited to",
+						Optional:            true,
+					},
+					"date": schema.StringAttribute{
+						MarkdownDescription: "Date after which results may be published",
+						Optional:            true,
+					},
+					"months": schema.Int64Attribute{
+						MarkdownDescription: "Number of months the use is limited to",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	},
+}
+
+// duoRestrictionValueKey is the key REMS keeps the values of a restriction under -
+// MONDO codes are ids, everything else is free text.
+func duoRestrictionValueKey(restrictionType string) string {
+	if restrictionType == "mondo" {
+		return "id"
+	}
+
+	return "value"
+}
+
+// restrictions converts the restriction into the generic form of REMS.
+func (r ResourceDuoRestrictionResourceModel) restrictions(ctx context.Context) ([]remsclient.ValidateRequestDuoCodesRestrictions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var result []remsclient.ValidateRequestDuoCodesRestrictions
+
+	add := func(restrictionType string, values ...string) {
+		restriction := remsclient.ValidateRequestDuoCodesRestrictions{Type: restrictionType}
+		key := duoRestrictionValueKey(restrictionType)
+
+		for _, v := range values {
+			restriction.Values = append(restriction.Values, map[string]interface{}{key: v})
+		}
+
+		result = append(result, restriction)
+	}
+
+	if !r.Mondo.IsNull() {
+		mondo := []string{}
+		diags.Append(r.Mondo.ElementsAs(ctx, &mondo, false)...)
+		add("mondo", mondo...)
+	}
+
+	for _, text := range []struct {
+		restrictionType string
+		value           types.String
+	}{
+		{"topic", r.Topic},
+		{"location", r.Location},
+		{"institute", r.Institute},
+		{"collaboration", r.Collaboration},
+		{"project", r.Project},
+		{"users", r.Users},
+		{"date", r.Date},
+	} {
+		if !text.value.IsNull() {
+			add(text.restrictionType, text.value.ValueString())
+		}
+	}
+
+	if !r.Months.IsNull() {
+		add("months", strconv.FormatInt(r.Months.ValueInt64(), 10))
+	}
+
+	return result, diags
+}
+
+// newDuoCodes converts the duo_code blocks into the DUO codes of a create command.
+func newDuoCodes(ctx context.Context, list types.List) ([]remsclient.DuoCode, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	codes := []ResourceDuoCodeResourceModel{}
+	diags.Append(list.ElementsAs(ctx, &codes, false)...)
+
+	result := make([]remsclient.DuoCode, 0, len(codes))
+
+	for _, c := range codes {
+		code := remsclient.NewDuoCode(c.Id.ValueString())
+
+		if !c.MoreInfo.IsNull() {
+			moreInfo := map[string]string{}
+			diags.Append(c.MoreInfo.ElementsAs(ctx, &moreInfo, false)...)
+			code.MoreInfo = &moreInfo
+		}
+
+		if !c.Restriction.IsNull() {
+			var restriction ResourceDuoRestrictionResourceModel
+			diags.Append(c.Restriction.As(ctx, &restriction, basetypes.ObjectAsOptions{})...)
+
+			restrictions, d := restriction.restrictions(ctx)
+			diags.Append(d...)
+
+			code.Restrictions = restrictions
+		}
+
+		result = append(result, *code)
+	}
+
+	return result, diags
+}
+
+// duoCodesValue converts the DUO codes of a resource as returned by REMS into the
+// value of the duo_code blocks.
+func duoCodesValue(ctx context.Context, codes []remsclient.DuoCodeFull) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	models := make([]ResourceDuoCodeResourceModel, 0, len(codes))
+
+	for _, c := range codes {
+		model := ResourceDuoCodeResourceModel{
+			Id:          types.StringValue(c.Id),
+			MoreInfo:    types.MapNull(types.StringType),
+			Restriction: types.ObjectNull(duoRestrictionType.AttrTypes),
+		}
+
+		if c.MoreInfo != nil && len(*c.MoreInfo) > 0 {
+			model.MoreInfo, d = types.MapValueFrom(ctx, types.StringType, *c.MoreInfo)
+			diags.Append(d...)
+		}
+
+		if len(c.Restrictions) > 0 {
+			restriction, d := DuoRestrictionValue(ctx, c.Id, c.Restrictions)
+			diags.Append(d...)
+
+			model.Restriction, d = types.ObjectValueFrom(ctx, duoRestrictionType.AttrTypes, restriction)
+			diags.Append(d...)
+		}
+
+		models = append(models, model)
+	}
+
+	list, d := types.ListValueFrom(ctx, duoCodeType, models)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// DuoRestrictionValue converts the generic restrictions of a DUO code as returned
+// by REMS into the typed restriction.
+func DuoRestrictionValue(ctx context.Context, codeId string, restrictions []remsclient.Response7799ResourcesDuoCodesRestrictions) (ResourceDuoRestrictionResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	restriction := ResourceDuoRestrictionResourceModel{
+		Mondo:         types.ListNull(types.StringType),
+		Topic:         types.StringNull(),
+		Location:      types.StringNull(),
+		Institute:     types.StringNull(),
+		Collaboration: types.StringNull(),
+		Project:       types.StringNull(),
+		Users:         types.StringNull(),
+		Date:          types.StringNull(),
+		Months:        types.Int64Null(),
+	}
+
+	for _, r := range restrictions {
+		values := make([]string, 0, len(r.Values))
+
+		for _, v := range r.Values {
+			values = append(values, duoRestrictionText(r.Type, v))
+		}
+
+		// every type but mondo has a single value
+		text := types.StringNull()
+		if len(values) > 0 {
+			text = types.StringValue(strings.Join(values, ", "))
+		}
+
+		switch r.Type {
+		case "mondo":
+			var d diag.Diagnostics
+			restriction.Mondo, d = types.ListValueFrom(ctx, types.StringType, values)
+			diags.Append(d...)
+		case "topic":
+			restriction.Topic = text
+		case "location":
+			restriction.Location = text
+		case "institute":
+			restriction.Institute = text
+		case "collaboration":
+			restriction.Collaboration = text
+		case "project":
+			restriction.Project = text
+		case "users":
+			restriction.Users = text
+		case "date":
+			restriction.Date = text
+		case "months":
+			if months, err := strconv.ParseInt(text.ValueString(), 10, 64); err == nil {
+				restriction.Months = types.Int64Value(months)
+			}
+		default:
+			tflog.Warn(ctx, "ignoring unsupported DUO restriction", map[string]interface{}{"code": codeId, "type": r.Type})
+		}
+	}
+
+	return restriction, diags
+}
+
+// duoRestrictionText is the text of a restriction value as returned by REMS.
+func duoRestrictionText(restrictionType string, value map[string]interface{}) string {
+	if v, ok := value[duoRestrictionValueKey(restrictionType)]; ok {
+		return fmt.Sprint(v)
+	}
+
+	for _, key := range []string{"id", "value"} {
+		if v, ok := value[key]; ok {
+			return fmt.Sprint(v)
+		}
+	}
+
+	return ""
+}
+
+// validateDuoCodes checks the DUO codes, and the MONDO codes of their restrictions,
+// against the codes the REMS server knows. Codes that are not yet known are skipped.
+// The codes of the server are fetched once for each provider, as the MONDO list
+// is large and every plan of a resource with DUO codes checks them.
+func validateDuoCodes(ctx context.Context, client *remsclient.APIClient, list types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if list.IsNull() || list.IsUnknown() {
+		return diags
+	}
+
+	codes := []ResourceDuoCodeResourceModel{}
+	diags.Append(list.ElementsAs(ctx, &codes, false)...)
+
+	if diags.HasError() || len(codes) == 0 {
+		return diags
+	}
+
+	knownDuo, duoResponse, duoErr := knownCodes.get(client, "duo")
+
+	if duoErr != nil {
+		diags.AddError(
+			"Failure to read DUO codes",
+			fmt.Sprintf("Could not read DUO codes to check the resource: %s %v", duoErr.Error(), duoResponse),
+		)
+		return diags
+	}
+
+	for i, c := range codes {
+		if !c.Id.IsUnknown() && !knownDuo[c.Id.ValueString()] {
+			diags.AddAttributeError(
+				path.Root("duo_code").AtListIndex(i).AtName("id"),
+				"Unknown DUO code",
+				fmt.Sprintf("REMS does not know the DUO code %q. The remscontent_duo_codes data source lists the codes it does.", c.Id.ValueString()),
+			)
+		}
+
+		if c.Restriction.IsNull() || c.Restriction.IsUnknown() {
+			continue
+		}
+
+		var restriction ResourceDuoRestrictionResourceModel
+		diags.Append(c.Restriction.As(ctx, &restriction, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+
+		if restriction.Mondo.IsNull() || restriction.Mondo.IsUnknown() {
+			continue
+		}
+
+		knownMondo, mondoResponse, mondoErr := knownCodes.get(client, "mondo")
+
+		if mondoErr != nil {
+			diags.AddError(
+				"Failure to read MONDO codes",
+				fmt.Sprintf("Could not read MONDO codes to check the resource: %s %v", mondoErr.Error(), mondoResponse),
+			)
+			return diags
+		}
+
+		unknown := []string{}
+
+		for _, v := range restriction.Mondo.Elements() {
+			if value, ok := v.(types.String); ok && !value.IsUnknown() && !knownMondo[value.ValueString()] {
+				unknown = append(unknown, fmt.Sprintf("%q", value.ValueString()))
+			}
+		}
+
+		if len(unknown) > 0 {
+			diags.AddAttributeError(
+				path.Root("duo_code").AtListIndex(i).AtName("restriction").AtName("mondo"),
+				"Unknown MONDO code",
+				fmt.Sprintf("REMS does not know the MONDO code(s) %s. The remscontent_mondo_codes data source can look codes up by label.", strings.Join(unknown, ", ")),
+			)
+		}
+	}
+
+	return diags
+}
+
+// knownCodesCache holds the DUO or MONDO codes REMS knows of for each client.
+// They come with REMS itself rather than being managed through it, so they do
+// not change while the provider runs.
+type knownCodesCache struct {
+	mu    sync.Mutex
+	codes map[knownCodesKey]map[string]bool
+}
+
+type knownCodesKey struct {
+	client *remsclient.APIClient
+	kind   string
+}
+
+var knownCodes = &knownCodesCache{codes: map[knownCodesKey]map[string]bool{}}
+
+// get returns the codes of the kind ("duo" or "mondo") known to the client,
+// fetching them the first time they are asked for.
+func (c *knownCodesCache) get(client *remsclient.APIClient, kind string) (map[string]bool, *http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := knownCodesKey{client: client, kind: kind}
+
+	if codes, ok := c.codes[key]; ok {
+		return codes, nil, nil
+	}
+
+	codes := map[string]bool{}
+
+	switch kind {
+	case "duo":
+		list, response, err := client.ResourcesAPI.ApiResourcesDuoCodesGet(context.Background()).Execute()
+
+		if err != nil {
+			return nil, response, err
+		}
+
+		for _, code := range list {
+			codes[code.Id] = true
+		}
+	case "mondo":
+		list, response, err := client.ResourcesAPI.ApiResourcesMondoCodesGet(context.Background()).Execute()
+
+		if err != nil {
+			return nil, response, err
+		}
+
+		for _, code := range list {
+			codes[code.Id] = true
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown kind of code %q", kind)
+	}
+
+	c.codes[key] = codes
+
+	return codes, nil, nil
+}
@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// duoRestriction is a restriction with only the given attributes set.
+func duoRestriction(set func(r *ResourceDuoRestrictionResourceModel)) types.Object {
+	restriction := ResourceDuoRestrictionResourceModel{
+		Mondo:         types.ListNull(types.StringType),
+		Topic:         types.StringNull(),
+		Location:      types.StringNull(),
+		Institute:     types.StringNull(),
+		Collaboration: types.StringNull(),
+		Project:       types.StringNull(),
+		Users:         types.StringNull(),
+		Date:          types.StringNull(),
+		Months:        types.Int64Null(),
+	}
+
+	set(&restriction)
+
+	value, _ := types.ObjectValueFrom(context.Background(), duoRestrictionType.AttrTypes, restriction)
+
+	return value
+}
+
+func duoCodesList(t *testing.T, codes ...ResourceDuoCodeResourceModel) types.List {
+	t.Helper()
+
+	list, diags := types.ListValueFrom(context.Background(), duoCodeType, codes)
+	requireNoErrors(t, diags)
+
+	return list
+}
+
+func TestNewDuoCodes(t *testing.T) {
+	list := duoCodesList(t,
+		ResourceDuoCodeResourceModel{
+			Id:       types.StringValue("DUO:0000007"),
+			MoreInfo: types.MapValueMust(types.StringType, map[string]attr.Value{"en": types.StringValue("Cancers only")}),
+			Restriction: duoRestriction(func(r *ResourceDuoRestrictionResourceModel) {
+				r.Mondo = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("MONDO:0004992"), types.StringValue("MONDO:0005070")})
+			}),
+		},
+		ResourceDuoCodeResourceModel{
+			Id:       types.StringValue("DUO:0000024"),
+			MoreInfo: types.MapNull(types.StringType),
+			Restriction: duoRestriction(func(r *ResourceDuoRestrictionResourceModel) {
+				r.Location = types.StringValue("Australia")
+				r.Months = types.Int64Value(12)
+			}),
+		},
+		ResourceDuoCodeResourceModel{
+			Id:          types.StringValue("DUO:0000042"),
+			MoreInfo:    types.MapNull(types.StringType),
+			Restriction: types.ObjectNull(duoRestrictionType.AttrTypes),
+		},
+	)
+
+	codes, diags := newDuoCodes(context.Background(), list)
+	requireNoErrors(t, diags)
+
+	assert.Equal(t, []remsclient.DuoCode{
+		{
+			Id:       "DUO:0000007",
+			MoreInfo: &map[string]string{"en": "Cancers only"},
+			Restrictions: []remsclient.ValidateRequestDuoCodesRestrictions{
+				{Type: "mondo", Values: []map[string]interface{}{{"id": "MONDO:0004992"}, {"id": "MONDO:0005070"}}},
+			},
+		},
+		{
+			Id: "DUO:0000024",
+			Restrictions: []remsclient.ValidateRequestDuoCodesRestrictions{
+				{Type: "location", Values: []map[string]interface{}{{"value": "Australia"}}},
+				{Type: "months", Values: []map[string]interface{}{{"value": "12"}}},
+			},
+		},
+		{
+			Id: "DUO:0000042",
+		},
+	}, codes)
+}
+
+func TestDuoCodesValue(t *testing.T) {
+	codes := []remsclient.DuoCodeFull{
+		{
+			Id:       "DUO:0000007",
+			MoreInfo: &map[string]string{"en": "Cancers only"},
+			Restrictions: []remsclient.Response7799ResourcesDuoCodesRestrictions{
+				{Type: "mondo", Values: []map[string]interface{}{{"id": "MONDO:0004992"}, {"id": "MONDO:0005070"}}},
+			},
+		},
+		{
+			Id:       "DUO:0000024",
+			MoreInfo: &map[string]string{},
+			Restrictions: []remsclient.Response7799ResourcesDuoCodesRestrictions{
+				{Type: "location", Values: []map[string]interface{}{{"value": "Australia"}}},
+				{Type: "months", Values: []map[string]interface{}{{"value": "12"}}},
+				{Type: "future", Values: []map[string]interface{}{{"value": "ignored"}}},
+			},
+		},
+		{
+			Id: "DUO:0000042",
+		},
+	}
+
+	list, diags := duoCodesValue(context.Background(), codes)
+	requireNoErrors(t, diags)
+
+	assert.Equal(t, duoCodesList(t,
+		ResourceDuoCodeResourceModel{
+			Id:       types.StringValue("DUO:0000007"),
+			MoreInfo: types.MapValueMust(types.StringType, map[string]attr.Value{"en": types.StringValue("Cancers only")}),
+			Restriction: duoRestriction(func(r *ResourceDuoRestrictionResourceModel) {
+				r.Mondo = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("MONDO:0004992"), types.StringValue("MONDO:0005070")})
+			}),
+		},
+		ResourceDuoCodeResourceModel{
+			Id:       types.StringValue("DUO:0000024"),
+			MoreInfo: types.MapNull(types.StringType),
+			Restriction: duoRestriction(func(r *ResourceDuoRestrictionResourceModel) {
+				r.Location = types.StringValue("Australia")
+				r.Months = types.Int64Value(12)
+			}),
+		},
+		ResourceDuoCodeResourceModel{
+			Id:          types.StringValue("DUO:0000042"),
+			MoreInfo:    types.MapNull(types.StringType),
+			Restriction: types.ObjectNull(duoRestrictionType.AttrTypes),
+		},
+	), list)
+}
+
+func TestDuoRestrictionText(t *testing.T) {
+	tests := map[string]struct {
+		restrictionType string
+		value           map[string]interface{}
+		expected        string
+	}{
+		"mondo id": {
+			restrictionType: "mondo",
+			value:           map[string]interface{}{"id": "MONDO:0004992", "label": "cancer"},
+			expected:        "MONDO:0004992",
+		},
+		"text value": {
+			restrictionType: "location",
+			value:           map[string]interface{}{"value": "Australia"},
+			expected:        "Australia",
+		},
+		"number value": {
+			restrictionType: "months",
+			value:           map[string]interface{}{"value": float64(12)},
+			expected:        "12",
+		},
+		"value under the other key": {
+			restrictionType: "topic",
+			value:           map[string]interface{}{"id": "genomics"},
+			expected:        "genomics",
+		},
+		"no value": {
+			restrictionType: "topic",
+			value:           map[string]interface{}{},
+			expected:        "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, duoRestrictionText(test.restrictionType, test.value))
+		})
+	}
+}
+
+func TestValidateDuoCodes(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/resources/duo-codes": `[
+	{"id": "DUO:0000007", "label": {"en": "disease specific research"}, "description": {"en": ""}}
+]`,
+		"GET /api/resources/mondo-codes": `[{"id": "MONDO:0004992", "label": "cancer"}]`,
+	})
+	client := f.Client()
+
+	mondo := func(ids ...string) types.Object {
+		return duoRestriction(func(r *ResourceDuoRestrictionResourceModel) {
+			values := []attr.Value{}
+			for _, id := range ids {
+				values = append(values, types.StringValue(id))
+			}
+			r.Mondo = types.ListValueMust(types.StringType, values)
+		})
+	}
+	code := func(id string, restriction types.Object) ResourceDuoCodeResourceModel {
+		return ResourceDuoCodeResourceModel{Id: types.StringValue(id), MoreInfo: types.MapNull(types.StringType), Restriction: restriction}
+	}
+
+	diags := validateDuoCodes(context.Background(), client, duoCodesList(t, code("DUO:0000007", mondo("MONDO:0004992"))))
+	requireNoErrors(t, diags)
+
+	diags = validateDuoCodes(context.Background(), client, duoCodesList(t,
+		code("DUO:0000099", types.ObjectNull(duoRestrictionType.AttrTypes)),
+		code("DUO:0000007", mondo("MONDO:0004992", "MONDO:9999999")),
+	))
+	require.Equal(t, 2, diags.ErrorsCount())
+	assert.Equal(t, "Unknown DUO code", diags.Errors()[0].Summary())
+	assert.Equal(t, "Unknown MONDO code", diags.Errors()[1].Summary())
+
+	// the codes are fetched once for the provider rather than on every plan
+	assert.Len(t, f.Requests, 2)
+}
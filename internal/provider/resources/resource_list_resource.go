@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &ResourceListResource{}
+var _ list.ListResourceWithConfigure = &ResourceListResource{}
+
+func NewResourceListResource() list.ListResource {
+	return &ResourceListResource{}
+}
+
+// ResourceListResource lists the resources of a REMS instance.
+type ResourceListResource struct {
+	client *remsclient.APIClient
+}
+
+// ResourceListResourceModel describes the list configuration.
+type ResourceListResourceModel struct {
+	listFilterModel
+	Resid types.String `tfsdk:"resid"`
+}
+
+func (r *ResourceListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource"
+}
+
+func (r *ResourceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := listFilterAttributes("resources")
+	attributes["resid"] = listschema.StringAttribute{
+		MarkdownDescription: "Only list resources with this external identifier",
+		Optional:            true,
+	}
+
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists resources",
+		Attributes:          attributes,
+	}
+}
+
+func (r *ResourceListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = listClient(req, resp)
+}
+
+func (r *ResourceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter ResourceListResourceModel
+
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	resourcesRequest := r.client.ResourcesAPI.
+		ApiResourcesGet(ctx).
+		Archived(filter.Archived.ValueBool()).
+		Disabled(filter.Disabled.ValueBool())
+
+	if !filter.Resid.IsNull() {
+		resourcesRequest = resourcesRequest.Resid(filter.Resid.ValueString())
+	}
+
+	remsResources, resourcesResponse, resourcesErr := resourcesRequest.Execute()
+
+	if resourcesErr != nil {
+		listHttpError(stream, "resources", resourcesErr, resourcesResponse)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, res := range remsResources {
+			if !filter.includes(res.Organization.OrganizationId) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+
+			result.DisplayName = res.Resid
+
+			identity := newOrganizationObjectIdentity(identityTypeResource, types.Int64Value(res.Id), types.StringValue(res.Organization.OrganizationId))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				var data ResourceResourceModel
+				result.Diagnostics.Append(data.fromResource(ctx, &res)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
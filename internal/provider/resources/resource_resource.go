@@ -7,20 +7,28 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/applications"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ResourceResource{}
 var _ resource.ResourceWithImportState = &ResourceResource{}
+var _ resource.ResourceWithIdentity = &ResourceResource{}
+var _ resource.ResourceWithModifyPlan = &ResourceResource{}
 
 func NewResourceResource() resource.Resource {
 	return &ResourceResource{}
@@ -28,14 +36,18 @@ func NewResourceResource() resource.Resource {
 
 // ResourceResource defines the resource implementation.
 type ResourceResource struct {
-	client *http.Client
+	client *remsclient.APIClient
 }
 
-// ResourceModel describes the resource data model.
+// ResourceResourceModel describes the resource data model.
 type ResourceResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	Id              types.Int64  `tfsdk:"id"`
+	OrganizationId  types.String `tfsdk:"organization_id"`
+	Resid           types.String `tfsdk:"resid"`
+	Licenses        types.List   `tfsdk:"licenses"`
+	DuoCodes        types.List   `tfsdk:"duo_code"`
+	RevokeOnDestroy types.Bool   `tfsdk:"revoke_on_destroy"`
+	RevokeComment   types.String `tfsdk:"revoke_comment"`
 }
 
 func (r *ResourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,42 +56,73 @@ func (r *ResourceResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *ResourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource resource",
+		MarkdownDescription: "Resource (a dataset that can be applied for). REMS does not allow resources to be edited so any change other than to the revoke settings replaces the resource.",
 
 		Attributes: map[string]schema.Attribute{
-			"configurable_attribute": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
-				Optional:            true,
-			},
-			"defaulted": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute with default value",
-				Optional:            true,
+			"id": schema.Int64Attribute{
 				Computed:            true,
-				Default:             stringdefault.StaticString("example value when not configured"),
+				MarkdownDescription: "Resource internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"id": schema.StringAttribute{
-				Computed:            true,
-				MarkdownDescription: "Example identifier",
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization that owns the resource",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resid": schema.StringAttribute{
+				MarkdownDescription: "External identifier of the resource, as used in entitlements (for instance a dataset URN)",
+				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"licenses": schema.ListAttribute{
+				MarkdownDescription: "Ids of licenses the applicant must accept",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"revoke_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Revoke the approved applications for the resource when it is destroyed, ending their " +
+					"entitlements rather than leaving them to expire. Defaults to `false`. The setting is taken from the " +
+					"state, so it must be applied before the run that destroys the resource. While it is set the resource " +
+					"cannot be replaced, as that would revoke the applications too - set it to `false` first.",
+				Optional: true,
+			},
+			"revoke_comment": schema.StringAttribute{
+				MarkdownDescription: "Reason for revoking shown to the applicants, when `revoke_on_destroy` is set",
+				Optional:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"duo_code": duoCodeBlock,
 		},
 	}
 }
 
+func (r *ResourceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationObjectIdentitySchema
+}
+
 func (r *ResourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*remsclient.APIClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -88,6 +131,52 @@ func (r *ResourceResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan checks the DUO codes of a new resource against those REMS knows, as
+// otherwise an unknown code fails only once applied. It also refuses to replace a
+// resource that revokes on destroy, as the replacement would revoke its
+// applications though the resource lives on.
+func (r *ResourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() && len(resp.RequiresReplace) > 0 {
+		var revokeOnDestroy types.Bool
+
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("revoke_on_destroy"), &revokeOnDestroy)...)
+
+		if revokeOnDestroy.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("revoke_on_destroy"),
+				"Resource cannot be replaced",
+				fmt.Sprintf("The change to %s replaces the resource, which would revoke its approved applications as "+
+					"revoke_on_destroy is set. Set revoke_on_destroy to false and apply that first.", resp.RequiresReplace),
+			)
+			return
+		}
+	}
+
+	// the codes cannot be checked until the provider is configured
+	if r.client == nil {
+		return
+	}
+
+	var planDuoCodes, stateDuoCodes types.List
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("duo_code"), &planDuoCodes)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("duo_code"), &stateDuoCodes)...)
+	}
+
+	if resp.Diagnostics.HasError() || planDuoCodes.Equal(stateDuoCodes) {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDuoCodes(ctx, r.client, planDuoCodes)...)
+}
+
 func (r *ResourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ResourceResourceModel
 
@@ -98,24 +187,56 @@ func (r *ResourceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	licenses := make([]int64, 0, len(data.Licenses.Elements()))
+	resp.Diagnostics.Append(data.Licenses.ElementsAs(ctx, &licenses, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	duoCodes, diags := newDuoCodes(ctx, data.DuoCodes)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgId := remsclient.NewOrganizationId(data.OrganizationId.ValueString())
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	resourceConfig := remsclient.NewCreateResourceCommand(data.Resid.ValueString(), *orgId, licenses)
+
+	if len(duoCodes) > 0 {
+		resourceConfig.ResourceDuo = &remsclient.CreateResourceCommandDuo{DuoCodes: duoCodes}
+	}
+
+	createResult, createResponse, createErr := r.client.ResourcesAPI.
+		ApiResourcesCreatePost(context.Background()).
+		CreateResourceCommand(*resourceConfig).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create resource",
+			fmt.Sprintf("Could not create resource: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to create resource",
+			fmt.Sprintf("Could not create resource: %v", createResult.GetErrors()),
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(createResult.GetId())
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created a resource")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ResourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -128,19 +249,48 @@ func (r *ResourceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	res, resResponse, resErr := r.client.ResourcesAPI.
+		ApiResourcesResourceIdGet(context.Background(), data.Id.ValueInt64()).
+		Execute()
+
+	if resResponse != nil && resResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if resErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read resource",
+			fmt.Sprintf("Could not read resource %d: %s %v", data.Id.ValueInt64(), resErr.Error(), resResponse),
+		)
+		return
+	}
+
+	// an archived resource is as good as deleted
+	if res.Archived {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkImportedOrganization(ctx, data.OrganizationId, req.Identity, res.Organization.OrganizationId)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromResource(ctx, res)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ResourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every attribute REMS knows of requires replacement, so only the provider side settings change in place
 	var data ResourceResourceModel
 
 	// Read Terraform plan data into the model
@@ -150,16 +300,9 @@ func (r *ResourceResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
-
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ResourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -172,15 +315,131 @@ func (r *ResourceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	if data.RevokeOnDestroy.ValueBool() {
+		resp.Diagnostics.Append(r.revokeApplications(data)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// REMS never deletes resources (applications refer to them) - the best we can do is archive
+	archiveResult, archiveResponse, archiveErr := r.client.ResourcesAPI.
+		ApiResourcesArchivedPut(context.Background()).
+		ArchivedCommand(*remsclient.NewArchivedCommand(data.Id.ValueInt64(), true)).
+		Execute()
+
+	if archiveErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to archive resource",
+			fmt.Sprintf("Could not archive resource %d: %s %v", data.Id.ValueInt64(), archiveErr.Error(), archiveResponse),
+		)
+		return
+	}
+
+	if !archiveResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to archive resource",
+			fmt.Sprintf("Could not archive resource %d: %v", data.Id.ValueInt64(), archiveResult.GetErrors()),
+		)
+	}
+}
+
+// revokeApplications revokes every approved application for the resource.
+func (r *ResourceResource) revokeApplications(data ResourceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	resid := data.Resid.ValueString()
+
+	// the search narrows the list down, the resources of each application decide
+	found, foundResponse, foundErr := r.client.ApplicationsAPI.
+		ApiApplicationsGet(context.Background()).
+		Query(fmt.Sprintf("resource:%q", resid)).
+		Execute()
+
+	if foundErr != nil {
+		diags.AddError(
+			"Failure to revoke applications",
+			fmt.Sprintf("Could not list applications for resource %s: %s %v", resid, foundErr.Error(), foundResponse),
+		)
+		return diags
+	}
+
+	revoked := []string{}
+
+	for _, a := range approvedApplicationsFor(found, data.Id.ValueInt64()) {
+		revokeCommand := remsclient.NewRevokeCommand(a.ApplicationId)
+		revokeCommand.Comment = data.RevokeComment.ValueStringPointer()
+
+		result, httpResp, err := r.client.ApplicationsAPI.
+			ApiApplicationsRevokePost(context.Background()).
+			RevokeCommand(*revokeCommand).
+			Execute()
+
+		diags.Append(applications.CommandDiagnostics("revoke", a.ApplicationId, result, httpResp, err)...)
+
+		if diags.HasError() {
+			diags.AddError(
+				"Failure to revoke applications",
+				fmt.Sprintf("Revoked %d application(s) for resource %s before failing, so the resource was not archived: %s",
+					len(revoked), resid, strings.Join(revoked, ", ")),
+			)
+			return diags
+		}
+
+		revoked = append(revoked, a.ApplicationExternalId)
+	}
+
+	diags.AddWarning(
+		"Revoked applications",
+		fmt.Sprintf("Revoked %d application(s) for resource %s: %s", len(revoked), resid, strings.Join(revoked, ", ")),
+	)
+
+	return diags
+}
+
+// approvedApplicationsFor picks the approved applications for the resource out of
+// those a search found, which may match the resid of other resources too.
+func approvedApplicationsFor(found []remsclient.ApplicationOverview, resourceId int64) []remsclient.ApplicationOverview {
+	approved := []remsclient.ApplicationOverview{}
+
+	for _, a := range found {
+		if a.ApplicationState == applications.StateApproved && slices.ContainsFunc(a.ApplicationResources, func(res remsclient.V2Resource) bool {
+			return res.ResourceId == resourceId
+		}) {
+			approved = append(approved, a)
+		}
+	}
+
+	return approved
 }
 
 func (r *ResourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateInt64Id(ctx, identityTypeResource, req, resp)
+}
+
+// fromResource sets the model from a resource as returned by REMS.
+func (data *ResourceResourceModel) fromResource(ctx context.Context, res *remsclient.Resource) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.Int64Value(res.Id)
+	data.OrganizationId = types.StringValue(res.Organization.OrganizationId)
+	data.Resid = types.StringValue(res.Resid)
+
+	licenses := make([]int64, 0, len(res.Licenses))
+	for _, l := range res.Licenses {
+		licenses = append(licenses, l.Id)
+	}
+
+	data.Licenses = listValueUnlessUnset(ctx, types.Int64Type, data.Licenses, licenses, &diags)
+
+	var d diag.Diagnostics
+	data.DuoCodes, d = duoCodesValue(ctx, res.GetResourceDuo().DuoCodes)
+	diags.Append(d...)
+
+	return diags
+}
+
+func (data *ResourceResourceModel) identity() organizationObjectIdentityModel {
+	return newOrganizationObjectIdentity(identityTypeResource, data.Id, data.OrganizationId)
 }
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umccr/terraform-provider-remscontent/internal/provider/applications"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+func resourceModel(t *testing.T, id types.Int64, licenses ...int64) ResourceResourceModel {
+	model := ResourceResourceModel{
+		Id:             id,
+		OrganizationId: types.StringValue("umccr"),
+		Resid:          types.StringValue("urn:example:dataset:1"),
+		Licenses:       types.ListNull(types.Int64Type),
+		DuoCodes:       types.ListValueMust(duoCodeType, []attr.Value{}),
+	}
+
+	if licenses != nil {
+		var diags diag.Diagnostics
+		model.Licenses, diags = types.ListValueFrom(context.Background(), types.Int64Type, licenses)
+		requireNoErrors(t, diags)
+	}
+
+	return model
+}
+
+func resourceJson(licenses string) string {
+	return `{
+	"id": 12,
+	"resid": "urn:example:dataset:1",
+	"organization": {"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}},
+	"enabled": true,
+	"archived": false,
+	"licenses": ` + licenses + `
+}`
+}
+
+const resourceLicenseJson = `{"id": 7, "licensetype": "link", "organization": {"organization/id": "umccr", "organization/short-name": {}, "organization/name": {}}, "enabled": true, "archived": false, "localizations": {}}`
+
+func TestResourceResourceCreate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"POST /api/resources/create": `{"success": true, "id": 12}`,
+	})
+	r := newTestResource(t, NewResourceResource(), f)
+
+	state, diags := r.Create(t, resourceModel(t, types.Int64Unknown(), 7))
+	requireNoErrors(t, diags)
+
+	var created ResourceResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &created))
+	assert.Equal(t, types.Int64Value(12), created.Id)
+
+	body := f.Request(t, "POST /api/resources/create").Body
+	assert.Equal(t, "urn:example:dataset:1", body["resid"])
+	assert.Equal(t, map[string]interface{}{"organization/id": "umccr"}, body["organization"])
+	assert.Equal(t, []interface{}{float64(7)}, body["licenses"])
+}
+
+func TestResourceResourceRead(t *testing.T) {
+	tests := map[string]struct {
+		prior    ResourceResourceModel
+		licenses string
+		expected ResourceResourceModel
+	}{
+		"licenses": {
+			prior:    resourceModel(t, types.Int64Value(12)),
+			licenses: "[" + resourceLicenseJson + "]",
+			expected: resourceModel(t, types.Int64Value(12), 7),
+		},
+		"no licenses stay unset": {
+			prior:    resourceModel(t, types.Int64Value(12)),
+			licenses: "[]",
+			expected: resourceModel(t, types.Int64Value(12)),
+		},
+		"no licenses stay empty": {
+			prior:    resourceModel(t, types.Int64Value(12), []int64{}...),
+			licenses: "[]",
+			expected: resourceModel(t, types.Int64Value(12), []int64{}...),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := newFakeRems(t, map[string]string{
+				"GET /api/resources/12": resourceJson(test.licenses),
+			})
+			r := newTestResource(t, NewResourceResource(), f)
+
+			state, diags := r.Read(t, test.prior)
+			requireNoErrors(t, diags)
+
+			var read ResourceResourceModel
+			requireNoErrors(t, state.Get(context.Background(), &read))
+			assert.Equal(t, test.expected, read)
+		})
+	}
+}
+
+func TestResourceResourceReadMissing(t *testing.T) {
+	r := newTestResource(t, NewResourceResource(), newFakeRems(t, map[string]string{}))
+
+	state, diags := r.Read(t, resourceModel(t, types.Int64Value(12)))
+	requireNoErrors(t, diags)
+	assert.True(t, state.Raw.IsNull(), "expected the resource to be removed from state")
+}
+
+func TestResourceResourceDelete(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/resources/archived": `{"success": true}`,
+	})
+	r := newTestResource(t, NewResourceResource(), f)
+
+	requireNoErrors(t, r.Delete(t, resourceModel(t, types.Int64Value(12))))
+
+	assert.Equal(t, map[string]interface{}{"id": float64(12), "archived": true}, f.Request(t, "PUT /api/resources/archived").Body)
+}
+
+func TestResourceResourceModifyPlanReplace(t *testing.T) {
+	r := newTestResource(t, NewResourceResource(), newFakeRems(t, map[string]string{}))
+
+	tests := map[string]struct {
+		revokeOnDestroy types.Bool
+		expectError     bool
+	}{
+		"revoking":     {revokeOnDestroy: types.BoolValue(true), expectError: true},
+		"not revoking": {revokeOnDestroy: types.BoolValue(false)},
+		"unset":        {revokeOnDestroy: types.BoolNull()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			prior := resourceModel(t, types.Int64Value(12))
+			prior.RevokeOnDestroy = test.revokeOnDestroy
+
+			planned := resourceModel(t, types.Int64Unknown())
+			planned.Resid = types.StringValue("urn:example:dataset:2")
+			planned.RevokeOnDestroy = test.revokeOnDestroy
+
+			plan := tfsdk.Plan(r.state(t, planned))
+			resp := resource.ModifyPlanResponse{Plan: plan, RequiresReplace: path.Paths{path.Root("resid")}}
+
+			r.resource.(resource.ResourceWithModifyPlan).ModifyPlan(context.Background(), resource.ModifyPlanRequest{State: r.state(t, prior), Plan: plan}, &resp)
+
+			assert.Equal(t, test.expectError, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestApprovedApplicationsFor(t *testing.T) {
+	application := func(id int64, state string, resourceIds ...int64) remsclient.ApplicationOverview {
+		a := remsclient.ApplicationOverview{ApplicationId: id, ApplicationState: state}
+		for _, resourceId := range resourceIds {
+			a.ApplicationResources = append(a.ApplicationResources, remsclient.V2Resource{ResourceId: resourceId})
+		}
+		return a
+	}
+
+	found := []remsclient.ApplicationOverview{
+		application(1, applications.StateApproved, 12),
+		application(2, applications.StateSubmitted, 12),
+		application(3, applications.StateApproved, 13),
+		application(4, applications.StateApproved, 13, 12),
+		application(5, applications.StateRevoked, 12),
+	}
+
+	ids := []int64{}
+	for _, a := range approvedApplicationsFor(found, 12) {
+		ids = append(ids, a.ApplicationId)
+	}
+
+	assert.Equal(t, []int64{1, 4}, ids)
+}
@@ -0,0 +1,414 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserResource{}
+var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithIdentity = &UserResource{}
+
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+// UserResource defines the resource implementation.
+type UserResource struct {
+	client *remsclient.APIClient
+}
+
+// UserResourceModel describes the resource data model.
+type UserResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Userid        types.String `tfsdk:"userid"`
+	Name          types.String `tfsdk:"name"`
+	Email         types.String `tfsdk:"email"`
+	Organizations types.List   `tfsdk:"organizations"`
+	Attributes    types.Map    `tfsdk:"attributes"`
+}
+
+func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "User, such as a service account or a handler or reviewer who has not yet logged in. " +
+			"REMS cannot delete users so destroying this resource only removes it from the Terraform state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "User id, known only once the user exists in REMS. Refer to this rather than `userid` " +
+					"(for instance in workflow handlers) so that the user is created first.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"userid": schema.StringAttribute{
+				MarkdownDescription: "User id, as used in workflow handlers and as given by the identity provider",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the user",
+				Optional:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the user",
+				Optional:            true,
+			},
+			"organizations": schema.ListAttribute{
+				MarkdownDescription: "Ids of the organizations the user belongs to",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Any further attributes of the user, as they would come from the identity provider. " +
+					"Only string attributes can be managed; a user with attributes of other types in REMS is an error.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *UserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = userIdentitySchema
+}
+
+func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*remsclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizations, attributes, diags := data.userValues(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// creating a user that exists would silently take it over
+	users, usersResponse, usersErr := activeUsers.get(r.client, true)
+
+	if usersErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create user",
+			fmt.Sprintf("Could not check for an existing user %s: %s %v", data.Userid.ValueString(), usersErr.Error(), usersResponse),
+		)
+		return
+	}
+
+	if _, ok := users[data.Userid.ValueString()]; ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("userid"),
+			"User already exists",
+			fmt.Sprintf("User %s already exists in REMS. Import it to manage it with Terraform.", data.Userid.ValueString()),
+		)
+		return
+	}
+
+	userConfig := remsclient.NewCreateUserCommand(
+		data.Userid.ValueString(),
+		*remsclient.NewNullableString(data.Name.ValueStringPointer()),
+		*remsclient.NewNullableString(data.Email.ValueStringPointer()),
+	)
+	userConfig.Organizations = organizations
+	userConfig.AdditionalProperties = attributes
+
+	createResult, createResponse, createErr := r.client.UsersAPI.
+		ApiUsersCreatePost(context.Background()).
+		CreateUserCommand(*userConfig).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create user",
+			fmt.Sprintf("Could not create user %s: %s %v", data.Userid.ValueString(), createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to create user",
+			fmt.Sprintf("Could not create user %s: %v", data.Userid.ValueString(), createResult.GetErrors()),
+		)
+		return
+	}
+
+	activeUsers.forget(r.client)
+
+	data.Id = data.Userid
+
+	tflog.Trace(ctx, "created a user")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, usersResponse, usersErr := activeUsers.get(r.client, false)
+
+	if usersErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read user",
+			fmt.Sprintf("Could not read user %s: %s %v", data.Userid.ValueString(), usersErr.Error(), usersResponse),
+		)
+		return
+	}
+
+	user, ok := users[data.Userid.ValueString()]
+
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromUser(ctx, &user)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizations, attributes, diags := data.userValues(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userConfig := remsclient.NewEditUserCommand(
+		data.Userid.ValueString(),
+		*remsclient.NewNullableString(data.Name.ValueStringPointer()),
+		*remsclient.NewNullableString(data.Email.ValueStringPointer()),
+	)
+	userConfig.Organizations = organizations
+	userConfig.AdditionalProperties = attributes
+
+	editResult, editResponse, editErr := r.client.UsersAPI.
+		ApiUsersEditPut(context.Background()).
+		EditUserCommand(*userConfig).
+		Execute()
+
+	if editErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to edit user",
+			fmt.Sprintf("Could not edit user %s: %s %v", data.Userid.ValueString(), editErr.Error(), editResponse),
+		)
+		return
+	}
+
+	if !editResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to edit user",
+			fmt.Sprintf("Could not edit user %s: %v", data.Userid.ValueString(), editResult.GetErrors()),
+		)
+		return
+	}
+
+	activeUsers.forget(r.client)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// REMS has no way to delete or archive a user (applications and events refer to them)
+	tflog.Debug(ctx, "users are left in REMS when removed from Terraform")
+}
+
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("userid"), path.Root("userid"), req, resp)
+}
+
+// fromUser sets the model from a user as returned by REMS. Organizations and
+// attributes that are empty in REMS are left null if they are null in the model.
+// Attributes that are not strings cannot be represented and are an error.
+func (data *UserResourceModel) fromUser(ctx context.Context, user *remsclient.UserWithAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(user.Userid)
+	data.Userid = types.StringValue(user.Userid)
+	data.Name = types.StringPointerValue(user.Name.Get())
+	data.Email = types.StringPointerValue(user.Email.Get())
+
+	organizations := make([]string, 0, len(user.Organizations))
+	for _, o := range user.Organizations {
+		organizations = append(organizations, o.OrganizationId)
+	}
+
+	data.Organizations = listValueUnlessUnset(ctx, types.StringType, data.Organizations, organizations, &diags)
+
+	attributes := map[string]string{}
+
+	for key, value := range user.AdditionalProperties {
+		text, ok := value.(string)
+
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("attributes").AtMapKey(key),
+				"Unsupported user attribute",
+				fmt.Sprintf("Attribute %q of user %s is not a string (got %T). Only users with string attributes can be managed.", key, user.Userid, value),
+			)
+			continue
+		}
+
+		attributes[key] = text
+	}
+
+	if len(attributes) > 0 || !data.Attributes.IsNull() {
+		var d diag.Diagnostics
+		data.Attributes, d = types.MapValueFrom(ctx, types.StringType, attributes)
+		diags.Append(d...)
+	} else {
+		data.Attributes = types.MapNull(types.StringType)
+	}
+
+	return diags
+}
+
+func (data *UserResourceModel) identity() userIdentityModel {
+	return userIdentityModel{
+		Userid: data.Userid,
+		Type:   types.StringValue(identityTypeUser),
+	}
+}
+
+// userValues converts the model into the shapes shared by the create and edit commands.
+func (data *UserResourceModel) userValues(ctx context.Context) ([]remsclient.OrganizationId, map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	organizationIds := []string{}
+	diags.Append(data.Organizations.ElementsAs(ctx, &organizationIds, false)...)
+
+	organizations := make([]remsclient.OrganizationId, 0, len(organizationIds))
+	for _, o := range organizationIds {
+		organizations = append(organizations, *remsclient.NewOrganizationId(o))
+	}
+
+	textAttributes := map[string]string{}
+	diags.Append(data.Attributes.ElementsAs(ctx, &textAttributes, false)...)
+
+	attributes := make(map[string]interface{}, len(textAttributes))
+	for key, value := range textAttributes {
+		attributes[key] = value
+	}
+
+	return organizations, attributes, diags
+}
+
+// activeUsersCache holds the users REMS knows of, by user id, for each client.
+// REMS has no endpoint for a single user, so without it refreshing every user
+// resource would fetch all users once per resource.
+type activeUsersCache struct {
+	mu    sync.Mutex
+	users map[*remsclient.APIClient]map[string]remsclient.UserWithAttributes
+}
+
+var activeUsers = &activeUsersCache{users: map[*remsclient.APIClient]map[string]remsclient.UserWithAttributes{}}
+
+// get returns the users of the client, fetching them unless they have been
+// fetched already and a refresh is not asked for.
+func (c *activeUsersCache) get(client *remsclient.APIClient, refresh bool) (map[string]remsclient.UserWithAttributes, *http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if users, ok := c.users[client]; ok && !refresh {
+		return users, nil, nil
+	}
+
+	list, response, err := client.UsersAPI.ApiUsersActiveGet(context.Background()).Execute()
+
+	if err != nil {
+		return nil, response, err
+	}
+
+	users := make(map[string]remsclient.UserWithAttributes, len(list))
+	for _, u := range list {
+		users[u.Userid] = u
+	}
+
+	c.users[client] = users
+
+	return users, response, nil
+}
+
+// forget drops the users of the client after a change, so the next get fetches them.
+func (c *activeUsersCache) forget(client *remsclient.APIClient) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.users, client)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func userModel(attributes map[string]attr.Value) UserResourceModel {
+	model := UserResourceModel{
+		Id:            types.StringValue("alice"),
+		Userid:        types.StringValue("alice"),
+		Name:          types.StringValue("Alice"),
+		Email:         types.StringNull(),
+		Organizations: types.ListNull(types.StringType),
+		Attributes:    types.MapNull(types.StringType),
+	}
+
+	if attributes != nil {
+		model.Attributes = types.MapValueMust(types.StringType, attributes)
+	}
+
+	return model
+}
+
+func TestUserResourceCreate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/users/active":  `[]`,
+		"POST /api/users/create": `{"success": true}`,
+	})
+	r := newTestResource(t, NewUserResource(), f)
+
+	plan := userModel(map[string]attr.Value{"eppn": types.StringValue("alice@example.org")})
+	plan.Id = types.StringUnknown()
+
+	state, diags := r.Create(t, plan)
+	requireNoErrors(t, diags)
+
+	var created UserResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &created))
+	assert.Equal(t, types.StringValue("alice"), created.Id)
+
+	body := f.Request(t, "POST /api/users/create").Body
+	assert.Equal(t, "alice", body["userid"])
+	assert.Equal(t, "alice@example.org", body["eppn"])
+}
+
+func TestUserResourceCreateExisting(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/users/active":  `[{"userid": "alice", "name": "Alice", "email": null}]`,
+		"POST /api/users/create": `{"success": true}`,
+	})
+	r := newTestResource(t, NewUserResource(), f)
+
+	plan := userModel(nil)
+	plan.Id = types.StringUnknown()
+
+	_, diags := r.Create(t, plan)
+	assert.True(t, diags.HasError(), "expected an existing user to be an error")
+
+	for _, request := range f.Requests {
+		assert.NotEqual(t, "POST /api/users/create", request.Route, "expected no user to be created")
+	}
+}
+
+func TestUserResourceRead(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/users/active": `[
+	{"userid": "bob", "name": "Bob", "email": null},
+	{"userid": "alice", "name": "Alice", "email": "alice@example.org", "eppn": "alice@example.org"}
+]`,
+	})
+	r := newTestResource(t, NewUserResource(), f)
+
+	state, diags := r.Read(t, userModel(nil))
+	requireNoErrors(t, diags)
+
+	var read UserResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+
+	expected := userModel(map[string]attr.Value{"eppn": types.StringValue("alice@example.org")})
+	expected.Email = types.StringValue("alice@example.org")
+	assert.Equal(t, expected, read)
+
+	// a second user of the same provider is found without asking REMS again
+	_, diags = r.Read(t, userModel(nil))
+	requireNoErrors(t, diags)
+	assert.Len(t, f.Requests, 1)
+}
+
+func TestUserResourceReadNonStringAttribute(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/users/active": `[{"userid": "alice", "name": "Alice", "email": null, "groups": ["staff"]}]`,
+	})
+	r := newTestResource(t, NewUserResource(), f)
+
+	_, diags := r.Read(t, userModel(nil))
+	assert.True(t, diags.HasError(), "expected a non-string attribute to be an error")
+}
+
+func TestUserResourceReadMissing(t *testing.T) {
+	r := newTestResource(t, NewUserResource(), newFakeRems(t, map[string]string{
+		"GET /api/users/active": `[]`,
+	}))
+
+	state, diags := r.Read(t, userModel(nil))
+	requireNoErrors(t, diags)
+	assert.True(t, state.Raw.IsNull(), "expected the user to be removed from state")
+}
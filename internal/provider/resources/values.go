@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listValueUnlessUnset converts values into a list, except that no values for a
+// list that was unset leaves it unset rather than flipping it to empty.
+func listValueUnlessUnset[T any](ctx context.Context, elementType attr.Type, prior types.List, values []T, diags *diag.Diagnostics) types.List {
+	if len(values) == 0 && prior.IsNull() {
+		return types.ListNull(elementType)
+	}
+
+	list, d := types.ListValueFrom(ctx, elementType, values)
+	diags.Append(d...)
+
+	return list
+}
+
+// LocalizedText picks the English text if there is one, otherwise the text of
+// the first language.
+func LocalizedText(text map[string]string) string {
+	if en, ok := text["en"]; ok {
+		return en
+	}
+
+	langs := make([]string, 0, len(text))
+	for lang := range text {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	if len(langs) == 0 {
+		return ""
+	}
+
+	return text[langs[0]]
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizedText(t *testing.T) {
+	tests := map[string]struct {
+		text     map[string]string
+		expected string
+	}{
+		"english":       {text: map[string]string{"fi": "Aineisto", "en": "Dataset"}, expected: "Dataset"},
+		"first by code": {text: map[string]string{"sv": "Datamängd", "fi": "Aineisto"}, expected: "Aineisto"},
+		"empty":         {text: map[string]string{}, expected: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, LocalizedText(test.text))
+		})
+	}
+}
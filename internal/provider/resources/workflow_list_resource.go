@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &WorkflowListResource{}
+var _ list.ListResourceWithConfigure = &WorkflowListResource{}
+
+func NewWorkflowListResource() list.ListResource {
+	return &WorkflowListResource{}
+}
+
+// WorkflowListResource lists the workflows of a REMS instance.
+type WorkflowListResource struct {
+	client *remsclient.APIClient
+}
+
+func (r *WorkflowListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow"
+}
+
+func (r *WorkflowListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists workflows",
+		Attributes:          listFilterAttributes("workflows"),
+	}
+}
+
+func (r *WorkflowListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = listClient(req, resp)
+}
+
+func (r *WorkflowListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter listFilterModel
+
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	workflows, workflowsResponse, workflowsErr := r.client.WorkflowsAPI.
+		ApiWorkflowsGet(ctx).
+		Archived(filter.Archived.ValueBool()).
+		Disabled(filter.Disabled.ValueBool()).
+		Execute()
+
+	if workflowsErr != nil {
+		listHttpError(stream, "workflows", workflowsErr, workflowsResponse)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, workflow := range workflows {
+			if !filter.includes(workflow.Organization.OrganizationId) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+
+			result.DisplayName = workflow.Title
+
+			identity := newOrganizationObjectIdentity(identityTypeWorkflow, types.Int64Value(workflow.Id), types.StringValue(workflow.Organization.OrganizationId))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				var data WorkflowResourceModel
+				result.Diagnostics.Append(data.fromWorkflow(ctx, &workflow)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -7,20 +7,27 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkflowResource{}
 var _ resource.ResourceWithImportState = &WorkflowResource{}
+var _ resource.ResourceWithIdentity = &WorkflowResource{}
+var _ resource.ResourceWithModifyPlan = &WorkflowResource{}
 
 func NewWorkflowResource() resource.Resource {
 	return &WorkflowResource{}
@@ -28,14 +35,18 @@ func NewWorkflowResource() resource.Resource {
 
 // WorkflowResource defines the resource implementation.
 type WorkflowResource struct {
-	client *http.Client
+	client *remsclient.APIClient
 }
 
 // WorkflowResourceModel describes the resource data model.
 type WorkflowResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	Id             types.Int64  `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	Title          types.String `tfsdk:"title"`
+	Type           types.String `tfsdk:"type"`
+	Handlers       types.List   `tfsdk:"handlers"`
+	Forms          types.List   `tfsdk:"forms"`
+	Licenses       types.List   `tfsdk:"licenses"`
 }
 
 func (r *WorkflowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,39 +58,71 @@ func (r *WorkflowResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "Workflow resource",
 
 		Attributes: map[string]schema.Attribute{
-			"configurable_attribute": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
-				Optional:            true,
-			},
-			"defaulted": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute with default value",
-				Optional:            true,
+			"id": schema.Int64Attribute{
 				Computed:            true,
-				Default:             stringdefault.StaticString("example value when not configured"),
+				MarkdownDescription: "Workflow internal identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization that owns the workflow",
+				Required:            true,
 			},
-			"id": schema.StringAttribute{
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Workflow title",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Workflow type, one of `workflow/default`, `workflow/decider` or `workflow/master`",
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Example identifier",
+				Default:             stringdefault.StaticString("workflow/default"),
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"handlers": schema.ListAttribute{
+				MarkdownDescription: "User ids of the handlers of applications using this workflow",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"forms": schema.ListAttribute{
+				MarkdownDescription: "Ids of forms every application using this workflow must fill in",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"licenses": schema.ListAttribute{
+				MarkdownDescription: "Ids of licenses every applicant using this workflow must accept",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
 				},
 			},
 		},
 	}
 }
 
+func (r *WorkflowResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationObjectIdentitySchema
+}
+
 func (r *WorkflowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*remsclient.APIClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *remsclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -88,6 +131,74 @@ func (r *WorkflowResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan checks that the handlers known at plan time are users REMS will
+// accept as handlers, as otherwise the workflow fails only once applied. Handlers
+// that are unknown (such as the id of a remscontent_user yet to be created) are
+// left to REMS.
+func (r *WorkflowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when destroying or when the provider is not yet configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var planHandlers, stateHandlers types.List
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("handlers"), &planHandlers)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("handlers"), &stateHandlers)...)
+	}
+
+	if resp.Diagnostics.HasError() || planHandlers.IsNull() || planHandlers.IsUnknown() || planHandlers.Equal(stateHandlers) {
+		return
+	}
+
+	handlers := []string{}
+
+	for _, h := range planHandlers.Elements() {
+		if handler, ok := h.(types.String); ok && !handler.IsUnknown() && !handler.IsNull() {
+			handlers = append(handlers, handler.ValueString())
+		}
+	}
+
+	if len(handlers) == 0 {
+		return
+	}
+
+	actors, actorsResponse, actorsErr := r.client.WorkflowsAPI.
+		ApiWorkflowsActorsGet(context.Background()).
+		Execute()
+
+	if actorsErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read workflow actors",
+			fmt.Sprintf("Could not read workflow actors to check the handlers: %s %v", actorsErr.Error(), actorsResponse),
+		)
+		return
+	}
+
+	known := map[string]bool{}
+	for _, a := range actors {
+		known[a.Userid] = true
+	}
+
+	unknown := []string{}
+	for _, h := range handlers {
+		if !known[h] {
+			unknown = append(unknown, fmt.Sprintf("%q", h))
+		}
+	}
+
+	if len(unknown) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("handlers"),
+			"Unknown workflow handler",
+			fmt.Sprintf("REMS has no user that can act as a handler with the user id(s) %s. "+
+				"Check for typos, or manage the user with remscontent_user and refer to its id.", strings.Join(unknown, ", ")),
+		)
+	}
+}
+
 func (r *WorkflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data WorkflowResourceModel
 
@@ -98,24 +209,61 @@ func (r *WorkflowResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	var handlers []string
+	var forms []int64
+	var licenses []int64
+
+	resp.Diagnostics.Append(data.Handlers.ElementsAs(ctx, &handlers, false)...)
+	resp.Diagnostics.Append(data.Forms.ElementsAs(ctx, &forms, false)...)
+	resp.Diagnostics.Append(data.Licenses.ElementsAs(ctx, &licenses, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgId := remsclient.NewOrganizationId(data.OrganizationId.ValueString())
+
+	workflowConfig := remsclient.NewCreateWorkflowCommand(data.Type.ValueString(), *orgId, data.Title.ValueString())
+	workflowConfig.SetHandlers(handlers)
+
+	for _, f := range forms {
+		workflowConfig.Forms = append(workflowConfig.Forms, *remsclient.NewCreateWorkflowCommandForms(f))
+	}
+
+	for _, l := range licenses {
+		workflowConfig.Licenses = append(workflowConfig.Licenses, *remsclient.NewLicenseId(l))
+	}
+
+	createResult, createResponse, createErr := r.client.WorkflowsAPI.
+		ApiWorkflowsCreatePost(context.Background()).
+		CreateWorkflowCommand(*workflowConfig).
+		Execute()
+
+	if createErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to create workflow",
+			fmt.Sprintf("Could not create workflow: %s %v", createErr.Error(), createResponse),
+		)
+		return
+	}
+
+	if !createResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to create workflow",
+			fmt.Sprintf("Could not create workflow: %v", createResult.GetErrors()),
+		)
+		return
+	}
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	data.Id = types.Int64Value(createResult.GetId())
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	tflog.Trace(ctx, "created a workflow")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *WorkflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -128,16 +276,44 @@ func (r *WorkflowResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	workflow, workflowResponse, workflowErr := r.client.WorkflowsAPI.
+		ApiWorkflowsWorkflowIdGet(context.Background(), data.Id.ValueInt64()).
+		Execute()
+
+	if workflowResponse != nil && workflowResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if workflowErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to read workflow",
+			fmt.Sprintf("Could not read workflow %d: %s %v", data.Id.ValueInt64(), workflowErr.Error(), workflowResponse),
+		)
+		return
+	}
+
+	// an archived workflow is as good as deleted
+	if workflow.Archived {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkImportedOrganization(ctx, data.OrganizationId, req.Identity, workflow.Organization.OrganizationId)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromWorkflow(ctx, workflow)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *WorkflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -150,16 +326,43 @@ func (r *WorkflowResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	handlers := []string{}
+	resp.Diagnostics.Append(data.Handlers.ElementsAs(ctx, &handlers, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the organization, title and handlers can be changed in place
+	workflowConfig := remsclient.NewEditWorkflowCommand(data.Id.ValueInt64())
+	workflowConfig.SetOrganization(*remsclient.NewOrganizationId(data.OrganizationId.ValueString()))
+	workflowConfig.SetTitle(data.Title.ValueString())
+	workflowConfig.SetHandlers(handlers)
+
+	editResult, editResponse, editErr := r.client.WorkflowsAPI.
+		ApiWorkflowsEditPut(context.Background()).
+		EditWorkflowCommand(*workflowConfig).
+		Execute()
+
+	if editErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to edit workflow",
+			fmt.Sprintf("Could not edit workflow %d: %s %v", data.Id.ValueInt64(), editErr.Error(), editResponse),
+		)
+		return
+	}
+
+	if !editResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to edit workflow",
+			fmt.Sprintf("Could not edit workflow %d: %v", data.Id.ValueInt64(), editResult.GetErrors()),
+		)
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *WorkflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -172,15 +375,107 @@ func (r *WorkflowResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	// REMS never deletes workflows (applications refer to them) - the best we can do is archive
+	archiveResult, archiveResponse, archiveErr := r.client.WorkflowsAPI.
+		ApiWorkflowsArchivedPut(context.Background()).
+		ArchivedCommand(*remsclient.NewArchivedCommand(data.Id.ValueInt64(), true)).
+		Execute()
+
+	if archiveErr != nil {
+		resp.Diagnostics.AddError(
+			"Failure to archive workflow",
+			fmt.Sprintf("Could not archive workflow %d: %s %v", data.Id.ValueInt64(), archiveErr.Error(), archiveResponse),
+		)
+		return
+	}
+
+	if !archiveResult.Success {
+		resp.Diagnostics.AddError(
+			"Failure to archive workflow",
+			fmt.Sprintf("Could not archive workflow %d: %v", data.Id.ValueInt64(), archiveResult.GetErrors()),
+		)
+	}
 }
 
 func (r *WorkflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateInt64Id(ctx, identityTypeWorkflow, req, resp)
+}
+
+// fromWorkflow sets the model from a workflow as returned by REMS. The details of
+// the workflow come back as an untyped JSON object so are picked apart by hand.
+// Lists that are empty in REMS are left null if they are null in the model.
+func (data *WorkflowResourceModel) fromWorkflow(ctx context.Context, workflow *remsclient.Workflow) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.Int64Value(workflow.Id)
+	data.OrganizationId = types.StringValue(workflow.Organization.OrganizationId)
+	data.Title = types.StringValue(workflow.Title)
+
+	details := WorkflowDetails(workflow)
+
+	data.Type = types.StringValue(details.Type)
+
+	data.Handlers = listValueUnlessUnset(ctx, types.StringType, data.Handlers, details.Handlers, &diags)
+	data.Forms = listValueUnlessUnset(ctx, types.Int64Type, data.Forms, details.Forms, &diags)
+	data.Licenses = listValueUnlessUnset(ctx, types.Int64Type, data.Licenses, details.Licenses, &diags)
+
+	return diags
+}
+
+func (data *WorkflowResourceModel) identity() organizationObjectIdentityModel {
+	return newOrganizationObjectIdentity(identityTypeWorkflow, data.Id, data.OrganizationId)
+}
+
+// WorkflowDetail is the part of a REMS workflow that the generated client leaves
+// as an untyped map.
+type WorkflowDetail struct {
+	Type     string
+	Handlers []string
+	Forms    []int64
+	Licenses []int64
+}
+
+// WorkflowDetails picks the type, handlers, forms and licenses out of the untyped
+// workflow body REMS returns.
+func WorkflowDetails(workflow *remsclient.Workflow) WorkflowDetail {
+	var details WorkflowDetail
+
+	if t, ok := workflow.Workflow["type"].(string); ok {
+		details.Type = t
+	}
+
+	for _, item := range jsonObjects(workflow.Workflow["handlers"]) {
+		if userid, ok := item["userid"].(string); ok {
+			details.Handlers = append(details.Handlers, userid)
+		}
+	}
+
+	for _, item := range jsonObjects(workflow.Workflow["forms"]) {
+		if id, ok := item["form/id"].(float64); ok {
+			details.Forms = append(details.Forms, int64(id))
+		}
+	}
+
+	for _, item := range jsonObjects(workflow.Workflow["licenses"]) {
+		if id, ok := item["license/id"].(float64); ok {
+			details.Licenses = append(details.Licenses, int64(id))
+		}
+	}
+
+	return details
+}
+
+// jsonObjects returns the objects of a decoded JSON array, skipping anything else.
+func jsonObjects(value interface{}) []map[string]interface{} {
+	var objects []map[string]interface{}
+
+	items, _ := value.([]interface{})
+
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects
 }
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+func workflowModel(t *testing.T, id types.Int64, handlers []string, forms []int64) WorkflowResourceModel {
+	model := WorkflowResourceModel{
+		Id:             id,
+		OrganizationId: types.StringValue("umccr"),
+		Title:          types.StringValue("Default workflow"),
+		Type:           types.StringValue("workflow/default"),
+		Handlers:       types.ListNull(types.StringType),
+		Forms:          types.ListNull(types.Int64Type),
+		Licenses:       types.ListNull(types.Int64Type),
+	}
+
+	var diags diag.Diagnostics
+
+	if handlers != nil {
+		model.Handlers, diags = types.ListValueFrom(context.Background(), types.StringType, handlers)
+		requireNoErrors(t, diags)
+	}
+
+	if forms != nil {
+		model.Forms, diags = types.ListValueFrom(context.Background(), types.Int64Type, forms)
+		requireNoErrors(t, diags)
+	}
+
+	return model
+}
+
+const workflowJson = `{
+	"id": 4,
+	"title": "Default workflow",
+	"organization": {"organization/id": "umccr", "organization/short-name": {"en": "UMCCR"}, "organization/name": {"en": "UMCCR"}},
+	"enabled": true,
+	"archived": false,
+	"workflow": {
+		"type": "workflow/default",
+		"handlers": [{"userid": "alice", "name": "Alice"}, {"userid": "bob"}],
+		"forms": [{"form/id": 3}],
+		"licenses": []
+	}
+}`
+
+func TestWorkflowResourceCreate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"POST /api/workflows/create": `{"success": true, "id": 4}`,
+	})
+	r := newTestResource(t, NewWorkflowResource(), f)
+
+	state, diags := r.Create(t, workflowModel(t, types.Int64Unknown(), []string{"alice", "bob"}, []int64{3}))
+	requireNoErrors(t, diags)
+
+	var created WorkflowResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &created))
+	assert.Equal(t, types.Int64Value(4), created.Id)
+
+	body := f.Request(t, "POST /api/workflows/create").Body
+	assert.Equal(t, "workflow/default", body["type"])
+	assert.Equal(t, "Default workflow", body["title"])
+	assert.Equal(t, []interface{}{"alice", "bob"}, body["handlers"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"form/id": float64(3)}}, body["forms"])
+	assert.NotContains(t, body, "licenses")
+}
+
+func TestWorkflowResourceRead(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"GET /api/workflows/4": workflowJson,
+	})
+	r := newTestResource(t, NewWorkflowResource(), f)
+
+	state, diags := r.Read(t, workflowModel(t, types.Int64Value(4), nil, nil))
+	requireNoErrors(t, diags)
+
+	var read WorkflowResourceModel
+	requireNoErrors(t, state.Get(context.Background(), &read))
+
+	// the empty licenses of REMS stay unset as they are in the prior state
+	assert.Equal(t, workflowModel(t, types.Int64Value(4), []string{"alice", "bob"}, []int64{3}), read)
+}
+
+func TestWorkflowResourceUpdate(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/workflows/edit": `{"success": true}`,
+	})
+	r := newTestResource(t, NewWorkflowResource(), f)
+
+	plan := workflowModel(t, types.Int64Value(4), []string{"carol"}, []int64{3})
+	plan.Title = types.StringValue("Renamed workflow")
+
+	_, diags := r.Update(t, workflowModel(t, types.Int64Value(4), []string{"alice"}, []int64{3}), plan)
+	requireNoErrors(t, diags)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":           float64(4),
+		"organization": map[string]interface{}{"organization/id": "umccr"},
+		"title":        "Renamed workflow",
+		"handlers":     []interface{}{"carol"},
+	}, f.Request(t, "PUT /api/workflows/edit").Body)
+}
+
+func TestWorkflowResourceDelete(t *testing.T) {
+	f := newFakeRems(t, map[string]string{
+		"PUT /api/workflows/archived": `{"success": true}`,
+	})
+	r := newTestResource(t, NewWorkflowResource(), f)
+
+	requireNoErrors(t, r.Delete(t, workflowModel(t, types.Int64Value(4), nil, nil)))
+
+	assert.Equal(t, map[string]interface{}{"id": float64(4), "archived": true}, f.Request(t, "PUT /api/workflows/archived").Body)
+}
+
+func TestWorkflowDetails(t *testing.T) {
+	tests := map[string]struct {
+		workflow map[string]interface{}
+		expected WorkflowDetail
+	}{
+		"empty": {
+			workflow: map[string]interface{}{},
+			expected: WorkflowDetail{},
+		},
+		"everything": {
+			workflow: map[string]interface{}{
+				"type":     "workflow/decider",
+				"handlers": []interface{}{map[string]interface{}{"userid": "alice"}},
+				"forms":    []interface{}{map[string]interface{}{"form/id": float64(3)}},
+				"licenses": []interface{}{map[string]interface{}{"license/id": float64(7)}, map[string]interface{}{"license/id": float64(8)}},
+			},
+			expected: WorkflowDetail{Type: "workflow/decider", Handlers: []string{"alice"}, Forms: []int64{3}, Licenses: []int64{7, 8}},
+		},
+		"unexpected shapes are skipped": {
+			workflow: map[string]interface{}{
+				"type":     float64(1),
+				"handlers": []interface{}{"alice", map[string]interface{}{"name": "Bob"}},
+				"forms":    map[string]interface{}{"form/id": float64(3)},
+			},
+			expected: WorkflowDetail{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, WorkflowDetails(&remsclient.Workflow{Workflow: test.workflow}))
+		})
+	}
+}
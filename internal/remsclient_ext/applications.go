@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remsclient_ext
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// SaveDraftFieldValue is the value of a field to save in a draft application.
+// The swagger gives the value as either a string or a table, which the
+// generator turns into an object, so the generated SaveDraftCommandFieldValues
+// cannot hold a string.
+type SaveDraftFieldValue struct {
+	Form  int64       `json:"form"`
+	Field string      `json:"field"`
+	Value interface{} `json:"value"`
+}
+
+// SaveDraft saves the field values of a draft application as the given user.
+func SaveDraft(ctx context.Context, client *remsclient.APIClient, userId string, applicationId int64, values []SaveDraftFieldValue) (*remsclient.SuccessResponse, *http.Response, error) {
+	body, err := json.Marshal(struct {
+		ApplicationId int64                 `json:"application-id"`
+		FieldValues   []SaveDraftFieldValue `json:"field-values"`
+	}{applicationId, values})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := newRequest(ctx, client, http.MethodPost, "/api/applications/save-draft", bytes.NewReader(body), http.Header{
+		"Content-Type":   {"application/json"},
+		"Accept":         {"application/json"},
+		"x-rems-user-id": {userId},
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, respBody, err := do(client, req)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var result remsclient.SuccessResponse
+
+	if err := result.UnmarshalJSON(respBody); err != nil {
+		return nil, resp, fmt.Errorf("could not decode the result: %w", err)
+	}
+
+	return &result, resp, nil
+}
+
+// ExportApplications is the CSV of the submitted applications of a form. The
+// swagger gives the export no content type, so the generated
+// ApplicationsAPI.ApiApplicationsExportGet does not ask for CSV.
+func ExportApplications(ctx context.Context, client *remsclient.APIClient, formId int64) (string, *http.Response, error) {
+	req, err := newRequest(ctx, client, http.MethodGet, "/api/applications/export?form-id="+strconv.FormatInt(formId, 10), nil, http.Header{
+		"Accept": {"text/csv"},
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, body, err := do(client, req)
+
+	if err != nil {
+		return "", resp, err
+	}
+
+	return string(body), resp, nil
+}
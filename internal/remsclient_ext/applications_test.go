@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remsclient_ext
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveDraft(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /api/applications/save-draft", r.Method+" "+r.URL.Path)
+		assert.Equal(t, []string{"application/json"}, r.Header.Values("Content-Type"))
+		assert.Equal(t, []string{"alice"}, r.Header.Values("x-rems-user-id"))
+		assert.Equal(t, []string{"secret"}, r.Header.Values("x-rems-api-key"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+	"application-id": 7,
+	"field-values": [
+		{"form": 3, "field": "fld1", "value": "Alice"},
+		{"form": 3, "field": "fld2", "value": [[{"column": "name", "value": "Bob"}]]}
+	]
+}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true}`))
+	})
+
+	var table interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[[{"column": "name", "value": "Bob"}]]`), &table))
+
+	result, _, err := SaveDraft(context.Background(), client, "alice", 7, []SaveDraftFieldValue{
+		{Form: 3, Field: "fld1", Value: "Alice"},
+		{Form: 3, Field: "fld2", Value: table},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Success)
+}
+
+func TestExportApplications(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET /api/applications/export", r.Method+" "+r.URL.Path)
+		assert.Equal(t, "3", r.URL.Query().Get("form-id"))
+		assert.Equal(t, "text/csv", r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("id,state\n1,approved\n"))
+	})
+
+	export, resp, err := ExportApplications(context.Background(), client, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, "id,state\n1,approved\n", export)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+}
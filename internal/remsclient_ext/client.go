@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package remsclient_ext holds the calls of the REMS API that the generated
+// remsclient cannot make, written by hand so they survive the client being
+// regenerated. They share the configuration (server, headers, HTTP client) of
+// the remsclient.APIClient they are given. See internal/REMSCLIENT_NOTE.md.
+package remsclient_ext
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// newRequest is a request to path of the REMS API, in the way the generated
+// client would make it: the configured server, with the default headers
+// unless the request sets them itself.
+func newRequest(ctx context.Context, client *remsclient.APIClient, method string, path string, body io.Reader, header http.Header) (*http.Request, error) {
+	cfg := client.GetConfig()
+
+	base, err := cfg.ServerURLWithContext(ctx, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(base + path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Host != "" {
+		u.Host = cfg.Host
+	}
+
+	if cfg.Scheme != "" {
+		u.Scheme = cfg.Scheme
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	for name, value := range cfg.DefaultHeader {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	if cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", cfg.UserAgent)
+	}
+
+	return req, nil
+}
+
+// do sends the request and reads the body of the response. As with the generated
+// client, a status of 300 or more is an error but the response is still returned.
+func do(client *remsclient.APIClient, req *http.Request) (*http.Response, []byte, error) {
+	httpClient := client.GetConfig().HTTPClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+
+	if err != nil {
+		return resp, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return resp, nil, err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, body, fmt.Errorf("%s: %s", resp.Status, body)
+	}
+
+	return resp, body, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remsclient_ext
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// AddLicenseAttachment uploads content as a file of the given name. The REMS
+// swagger describes the upload in a way the generator does not understand, so
+// the generated LicensesAPI.ApiLicensesAddAttachmentPost sends no file.
+func AddLicenseAttachment(ctx context.Context, client *remsclient.APIClient, filename string, content []byte) (*remsclient.AddLicenseAttachmentResponse, *http.Response, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreateFormFile("file", filename)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return nil, nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := newRequest(ctx, client, http.MethodPost, "/api/licenses/add_attachment", body, http.Header{
+		"Content-Type": {w.FormDataContentType()},
+		"Accept":       {"application/json"},
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, respBody, err := do(client, req)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var result remsclient.AddLicenseAttachmentResponse
+
+	if err := result.UnmarshalJSON(respBody); err != nil {
+		return nil, resp, fmt.Errorf("could not decode the attachment: %w", err)
+	}
+
+	return &result, resp, nil
+}
+
+// LicenseAttachmentMetadata is what REMS says of an attachment without its content.
+type LicenseAttachmentMetadata struct {
+	Filename string
+}
+
+// GetLicenseAttachmentMetadata asks for the headers of an attachment, so its
+// existence and name can be checked without downloading it.
+func GetLicenseAttachmentMetadata(ctx context.Context, client *remsclient.APIClient, attachmentId int64) (*LicenseAttachmentMetadata, *http.Response, error) {
+	req, err := newRequest(ctx, client, http.MethodHead, fmt.Sprintf("/api/licenses/attachments/%d", attachmentId), nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, _, err := do(client, req)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	metadata := LicenseAttachmentMetadata{}
+
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		metadata.Filename = params["filename"]
+	}
+
+	return &metadata, resp, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remsclient_ext
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umccr/terraform-provider-remscontent/internal/remsclient"
+)
+
+// newTestClient is a client with default headers pointing at a server that
+// answers with handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *remsclient.APIClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverUrl, _ := url.Parse(server.URL)
+
+	cfg := remsclient.NewConfiguration()
+	cfg.Host = serverUrl.Host
+	cfg.Scheme = serverUrl.Scheme
+	cfg.HTTPClient = server.Client()
+	cfg.DefaultHeader = map[string]string{"x-rems-user-id": "owner", "x-rems-api-key": "secret"}
+
+	return remsclient.NewAPIClient(cfg)
+}
+
+func TestAddLicenseAttachment(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /api/licenses/add_attachment", r.Method+" "+r.URL.Path)
+		assert.Equal(t, []string{"owner"}, r.Header.Values("x-rems-user-id"))
+		assert.Equal(t, []string{"secret"}, r.Header.Values("x-rems-api-key"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "dta.pdf", header.Filename)
+		assert.Equal(t, "%PDF-1.4", string(content))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "id": 5}`))
+	})
+
+	result, _, err := AddLicenseAttachment(context.Background(), client, "dta.pdf", []byte("%PDF-1.4"))
+	require.NoError(t, err)
+
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(5), result.Id)
+}
+
+func TestGetLicenseAttachmentMetadata(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/licenses/attachments/5" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		w.Header().Set("Content-Disposition", `attachment; filename="dta.pdf"`)
+	})
+
+	metadata, _, err := GetLicenseAttachmentMetadata(context.Background(), client, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "dta.pdf", metadata.Filename)
+
+	_, resp, err := GetLicenseAttachmentMetadata(context.Background(), client, 6)
+	assert.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
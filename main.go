@@ -7,8 +7,10 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/umccr/terraform-provider-remscontent/internal/generate"
 	"github.com/umccr/terraform-provider-remscontent/internal/provider"
 )
 
@@ -22,6 +24,14 @@ var (
 )
 
 func main() {
+	// "generate" writes configuration for an existing REMS instance rather than serving the provider
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate.Main(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")